@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strconv"
+
+	tui "github.com/GrandOichii/go-termui"
+)
+
+func main() {
+	// create the window
+	w, _ := tui.CreateWindow("Constraints tester")
+	// extract the menu
+	menu := w.GetMenu()
+	// create the list on the left half of the window
+	options := []tui.DrawableAsLine{}
+	for i := 1; i <= 50; i++ {
+		option, _ := tui.ToCCTMessage("Option " + strconv.Itoa(i))
+		options = append(options, option)
+	}
+	list, _ := tui.NewList(menu, 0, 0, options, 5, func(choice, cursor int, option tui.DrawableAsLine) error {
+		return nil
+	}, "normal")
+	tui.SetConstraints(list, tui.Constraints{Height: tui.Percent(80).Min(5)})
+	// create the pie chart on the right half of the window
+	pie, _ := tui.NewPieChart(menu, 0, 0, 10, 20, []int{1, 2, 3}, []string{}, "normal")
+	tui.SetConstraints(pie, tui.Constraints{X: tui.Percent(50), Height: tui.Percent(80).Min(6), Width: tui.Fill()})
+	// create the progress bar at the bottom of the window
+	bar, _ := tui.NewProgressBar(menu, 0, 0, 10, 100, true, "normal", "normal")
+	bar.Set(42)
+	tui.SetConstraints(bar, tui.Constraints{Y: tui.Fill(), Width: tui.Fill()})
+	// focus on the list
+	menu.Focus(list)
+	// start the window
+	w.Start()
+}
@@ -12,7 +12,7 @@ func main() {
 	// create the button
 	button, _ := tui.NewButton(menu, 0, 0, "Press enter to click me!", func() error {
 		ddbOptions := []string{"Choose ${green}me", "or ${red}me", "${magenta-white}proably ${normal}me"}
-		result, _ := tui.DropDownBox(ddbOptions, 2, 1, 25, tui.SingleElement, "cyan-gray")
+		result, _ := tui.DropDownBox(w, ddbOptions, 2, 1, 25, tui.SingleElement, "cyan-gray")
 		if len(result) == 0 {
 			// user didn't choose anything
 			return nil
@@ -0,0 +1,45 @@
+package main
+
+import (
+	tui "github.com/GrandOichii/go-termui"
+)
+
+// A non-modal layer that shows a hint in the bottom right corner until F1 is pressed
+type hint struct {
+	w *tui.Window
+}
+
+func (h *hint) Draw(s tui.Surface) error {
+	height, width := s.MaxYX()
+	tui.Put(s, height-2, width-18, "F1 - hide the hint")
+	return nil
+}
+
+func (h *hint) HandleKey(key tui.Key) (bool, error) {
+	if key != tui.KeyF1 {
+		// the other keys go to the menu
+		return false, nil
+	}
+	h.w.RemoveLayer(h)
+	return true, nil
+}
+
+func main() {
+	// create the window
+	w, _ := tui.CreateWindow("Layers tester")
+	// extract the menu
+	menu := w.GetMenu()
+	// the dialogs are modal layers, opening a dialog from a dialog stacks them
+	button, _ := tui.NewButton(menu, 1, 1, "[delete]", func() error {
+		answer, err := tui.MessageBox(w, "Delete the file?", []string{"Yes", "No", "Cancel"}, "normal")
+		if err != nil || answer != "Yes" {
+			return err
+		}
+		_, err = tui.MessageBox(w, "${red}Deleted", []string{}, "normal")
+		return err
+	}, tui.KeyEnter)
+	menu.Focus(button)
+	w.PushLayer(&hint{w: w}, false)
+	// start the window
+	w.Start()
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	tui "github.com/GrandOichii/go-termui"
+)
+
+func main() {
+	// create the window
+	w, _ := tui.CreateWindow("Layout tester")
+	// extract the menu
+	menu := w.GetMenu()
+	// create the grid of the form, the locations of the elements are set by the grid
+	grid, _ := tui.NewGrid(menu, 1, 1, 1, 1)
+	fields := []*tui.LineEdit{}
+	for i, name := range []string{"Name:", "Surname:", "Age:"} {
+		label, _ := tui.NewLabel(menu, 0, 0, name)
+		lineedit, _ := tui.NewLineEdit(menu, 0, 0, "", 20, "normal")
+		grid.Add(label, i, 0)
+		grid.Add(lineedit, i, 1)
+		fields = append(fields, lineedit)
+	}
+	// create the row of buttons below the form
+	buttons, _ := tui.NewHBox(menu, 0, 0, 2)
+	ok, _ := tui.NewButton(menu, 0, 0, "[ok]", func() error {
+		tui.MessageBox(w, "Hello, ${red}"+fields[0].GetText(), []string{}, "normal")
+		return nil
+	}, tui.KeyEnter)
+	exit, _ := tui.NewButton(menu, 0, 0, "[exit]", func() error {
+		w.Exit()
+		return nil
+	}, tui.KeyEnter)
+	buttons.Add(ok, exit)
+	grid.AddSpan(buttons, 3, 0, 1, 2)
+	// link the elements
+	tui.Link(fields[0], fields[1], fields[2], ok, exit)
+	// focus on the first field
+	menu.Focus(fields[0])
+	// start the window
+	w.Start()
+}
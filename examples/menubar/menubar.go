@@ -0,0 +1,52 @@
+package main
+
+import (
+	tui "github.com/GrandOichii/go-termui"
+)
+
+func main() {
+	// create the window
+	w, _ := tui.CreateWindow("MenuBar tester")
+	// extract the menu
+	menu := w.GetMenu()
+	// the row below the menu bar is the first free row
+	status, _ := tui.NewLabel(menu, 2, 1, "Press alt+f or F10 to open the menu")
+	// create the menu bar after the elements below it
+	bar, _ := tui.NewMenuBar(menu, "normal")
+	say := func(text string) func() error {
+		return func() error {
+			return status.SetText(text)
+		}
+	}
+	// the & marks the accelerator letter
+	newItem, _ := tui.NewMenuItem("&New", say("New file"))
+	open, _ := tui.NewMenuItem("&Open", say("Open file"))
+	first, _ := tui.NewMenuItem("&1 notes.txt", say("Opened notes.txt"))
+	second, _ := tui.NewMenuItem("&2 todo.txt", say("Opened todo.txt"))
+	recent, _ := tui.NewSubmenu("&Recent", first, second)
+	save, _ := tui.NewMenuItem("&Save", say("Saved"))
+	// nothing to save yet
+	save.SetEnabled(false)
+	exit, _ := tui.NewMenuItem("E&xit", func() error {
+		w.RequestExit()
+		return nil
+	})
+	file, _ := tui.NewSubmenu("&File", newItem, open, recent, tui.NewMenuSeparator(), save, tui.NewMenuSeparator(), exit)
+	var wrap *tui.MenuItem
+	wrap, _ = tui.NewCheckMenuItem("&Word wrap", true, func() error {
+		if wrap.IsChecked() {
+			return status.SetText("Word wrap is on")
+		}
+		return status.SetText("Word wrap is off")
+	})
+	zoom, _ := tui.NewMenuItem("${cyan}&Zoom", say("Zoomed"))
+	view, _ := tui.NewSubmenu("&View", wrap, zoom)
+	about, _ := tui.NewMenuItem("&About", func() error {
+		_, err := tui.MessageBox(w, "MenuBar tester", []string{}, "normal")
+		return err
+	})
+	help, _ := tui.NewSubmenu("&Help", about)
+	bar.Add(file, view, help)
+	// start the window
+	w.Start()
+}
@@ -1,21 +1,32 @@
 package main
 
-import tui "github.com/GrandOichii/go-termui"
+import (
+	"fmt"
+
+	tui "github.com/GrandOichii/go-termui"
+)
 
 func main() {
 	w, _ := tui.CreateWindow("Menu 1")
 	firstMenu := w.GetMenu()
 	secondMenu, _ := tui.NewNormalMenu("${red-cyan}Menu 2")
+	visits := 0
+	counter, _ := tui.NewLabel(secondMenu, 1, 5, "")
 	b1, _ := tui.NewButton(firstMenu, 1, 1, "[click me]", func() error {
-		w.SetMenu(secondMenu)
-		return nil
+		return w.PushMenu(secondMenu)
 	}, tui.KeyEnter)
 	b2, _ := tui.NewButton(secondMenu, 5, 5, "${red-cyan}[go back]", func() error {
-		w.SetMenu(firstMenu)
-		return nil
+		return w.PopMenu()
 	}, tui.KeyEnter)
+	// refresh the menu every time it is shown
+	secondMenu.SetOnEnter(func() error {
+		visits++
+		return counter.SetText(fmt.Sprintf("Visits: %d", visits))
+	})
 	firstMenu.Focus(b1)
 	secondMenu.Focus(b2)
 	secondMenu.SetBorderColor("red-cyan")
+	// escape goes back to the previous menu, on the first menu it exits
+	w.SetBackKey(tui.KeyEscape)
 	w.Start()
 }
@@ -0,0 +1,36 @@
+package main
+
+import (
+	tui "github.com/GrandOichii/go-termui"
+)
+
+func main() {
+	// create the window
+	w, _ := tui.CreateWindow("Panel tester")
+	// extract the menu
+	menu := w.GetMenu()
+	// create the panel before its children
+	panel, _ := tui.NewPanel(menu, 1, 1, 6, 30, "${green}Account", "normal")
+	// the locations of the children are relative to the panel
+	nameLabel, _ := tui.NewLabel(menu, 1, 1, "Name:")
+	name, _ := tui.NewLineEdit(menu, 1, 7, "", 15, "normal")
+	save, _ := tui.NewButton(menu, 3, 7, "[save]", func() error {
+		tui.MessageBox(w, "Saved ${red}"+name.GetText(), []string{}, "normal")
+		return nil
+	}, tui.KeyEnter)
+	panel.Add(nameLabel, name, save)
+	tui.SetNext(name, save)
+	tui.SetPrev(save, name)
+	// the focus steps into the panel and out of it
+	exit, _ := tui.NewButton(menu, 8, 1, "[exit]", func() error {
+		w.Exit()
+		return nil
+	}, tui.KeyEnter)
+	tui.Link(panel, exit)
+	// moving the panel moves everything inside it
+	tui.SetYX(panel, 2, 4)
+	// focus on the first element of the panel
+	menu.Focus(panel)
+	// start the window
+	w.Start()
+}
@@ -1,8 +1,9 @@
 package main
 
 import (
+	"time"
+
 	tui "github.com/GrandOichii/go-termui"
-	"github.com/rthornton128/goncurses"
 )
 
 func checkErr(err error) {
@@ -18,16 +19,14 @@ func main() {
 	// tui.NewProgressBar(menu, 1, 1, 10, 100, true, "normal", "normal")
 	pb, err := tui.NewProgressBar(menu, 1, 1, 10, 100, true, "red", "cyan")
 	checkErr(err)
-	count := 0
 	go func() {
-		for {
-			goncurses.Nap(100)
-			count++
-			pb.Set(count)
-			w.GetMenu().Draw()
-			if count == 100 {
-				break
-			}
+		for count := 1; count <= 100; count++ {
+			time.Sleep(100 * time.Millisecond)
+			value := count
+			// update the progress bar on the window goroutine
+			w.QueueUpdate(func() {
+				pb.Set(value)
+			})
 		}
 	}()
 	w.Start()
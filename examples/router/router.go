@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	tui "github.com/GrandOichii/go-termui"
+)
+
+var users = []string{"alice", "bob", "carol"}
+
+func main() {
+	// create the window
+	w, _ := tui.CreateWindow("Router tester")
+	router := tui.NewRouter(w)
+	// escape goes back to the previous route
+	w.SetBackKey(tui.KeyEscape)
+	// the home route lists the users
+	router.Handle("home", func(params tui.RouteParams) (tui.Menu, error) {
+		menu, err := tui.NewNormalMenu("Users")
+		if err != nil {
+			return nil, err
+		}
+		buttons := []tui.UIElement{}
+		for i, user := range users {
+			id := strconv.Itoa(i)
+			button, _ := tui.NewButton(menu, i, 0, "["+user+"]", func() error {
+				return router.Navigate("user/" + id)
+			}, tui.KeyEnter)
+			buttons = append(buttons, button)
+		}
+		tui.Link(buttons...)
+		menu.Focus(buttons[0])
+		return menu, nil
+	})
+	// the user route shows the user with the id
+	router.Handle("user/:id", func(params tui.RouteParams) (tui.Menu, error) {
+		menu, err := tui.NewNormalMenu("User " + params["id"])
+		if err != nil {
+			return nil, err
+		}
+		name := "${red}unknown user"
+		id, err := strconv.Atoi(params["id"])
+		if err == nil && id >= 0 && id < len(users) {
+			name = users[id]
+		}
+		trail, _ := tui.NewLabel(menu, 0, 0, "")
+		tui.NewLabel(menu, 2, 0, "Name: "+name)
+		back, _ := tui.NewButton(menu, 4, 0, "[back]", func() error {
+			if !w.CanGoBack() {
+				// started on this route, there is no previous route
+				return router.Replace("home")
+			}
+			return router.Back()
+		}, tui.KeyEnter)
+		menu.Focus(back)
+		// show the breadcrumbs when the menu is shown
+		menu.SetOnEnter(func() error {
+			return trail.SetText(strings.Join(router.Breadcrumbs(), " > "))
+		})
+		return menu, nil
+	})
+	// start on the route passed on the command line, e.g. "user/1"
+	route := "home"
+	if len(os.Args) > 1 {
+		route = os.Args[1]
+	}
+	router.Start(route)
+}
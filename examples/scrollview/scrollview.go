@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strconv"
+
+	tui "github.com/GrandOichii/go-termui"
+)
+
+func main() {
+	// create the window
+	w, _ := tui.CreateWindow("ScrollView tester")
+	// extract the menu
+	menu := w.GetMenu()
+	// create the scroll view before its children
+	view, _ := tui.NewScrollView(menu, 1, 1, 8, 40, "normal")
+	// the locations of the children are in the virtual space of the scroll view
+	fields := []tui.UIElement{}
+	for i := 0; i < 20; i++ {
+		label, _ := tui.NewLabel(menu, i*2, 0, "Field "+strconv.Itoa(i+1)+":")
+		field, _ := tui.NewLineEdit(menu, i*2, 10, "", 20, "normal")
+		view.Add(label, field)
+		fields = append(fields, field)
+	}
+	// the focused field is scrolled into view, PgUp and PgDn scroll by a page
+	tui.Link(fields...)
+	menu.Focus(fields[0])
+	// start the window
+	w.Start()
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strconv"
+
+	tui "github.com/GrandOichii/go-termui"
+)
+
+func main() {
+	// create the window
+	w, _ := tui.CreateWindow("Split tester")
+	// extract the menu
+	menu := w.GetMenu()
+	// create the panes, the split sets their locations and sizes
+	files, _ := tui.NewPanel(menu, 0, 0, 1, 1, "Files", "normal")
+	details, _ := tui.NewPanel(menu, 0, 0, 1, 1, "Details", "normal")
+	info, _ := tui.NewLabel(menu, 0, 0, "Nothing selected")
+	details.Add(info)
+	options := []tui.DrawableAsLine{}
+	for i := 1; i <= 30; i++ {
+		option, _ := tui.ToCCTMessage("file" + strconv.Itoa(i) + ".txt")
+		options = append(options, option)
+	}
+	list, _ := tui.NewList(menu, 0, 0, options, 5, func(choice, cursor int, option tui.DrawableAsLine) error {
+		return info.SetText("Selected ${green}" + option.(*tui.CCTMessage).ToString())
+	}, "normal")
+	files.Add(list)
+	// create the split, ctrl+left/right or dragging the divider moves it
+	split, _ := tui.NewSplit(menu, 0, 0, 1, 1, tui.SplitLeftRight, 20, "normal")
+	split.MinFirst = 10
+	split.MinSecond = 10
+	split.SetPanes(files, details)
+	tui.SetConstraints(split, tui.Constraints{Height: tui.Fill(), Width: tui.Fill()})
+	// focus on the list
+	menu.Focus(list)
+	// start the window
+	w.Start()
+}
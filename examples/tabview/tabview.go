@@ -0,0 +1,31 @@
+package main
+
+import (
+	tui "github.com/GrandOichii/go-termui"
+)
+
+func main() {
+	// create the window
+	w, _ := tui.CreateWindow("TabView tester")
+	// extract the menu
+	menu := w.GetMenu()
+	// create the tab view before its children
+	tabs, _ := tui.NewTabView(menu, 1, 1, 10, 40, "normal")
+	general, _ := tabs.AddTab("General")
+	advanced, _ := tabs.AddTab("${red}Advanced")
+	// the locations of the children are relative to the tab view
+	nameLabel, _ := tui.NewLabel(menu, 0, 0, "Name:")
+	name, _ := tui.NewLineEdit(menu, 0, 6, "", 20, "normal")
+	greet, _ := tui.NewButton(menu, 2, 6, "[greet]", func() error {
+		tui.MessageBox(w, "Hello, ${red}"+name.GetText(), []string{}, "normal")
+		return nil
+	}, tui.KeyEnter)
+	tabs.Add(general, nameLabel, name, greet)
+	tui.Link(name, greet)
+	mode, _ := tui.NewWordChoice(menu, 0, 0, []string{"fast", "safe", "silent"}, tui.AlignCenter, "normal")
+	tabs.Add(advanced, mode)
+	// ctrl+pgup/pgdn and alt+digit switch the tabs
+	menu.Focus(tabs)
+	// start the window
+	w.Start()
+}
@@ -1,22 +1,59 @@
 package termui
 
 import (
-	"C"
-
-	nc "github.com/rthornton128/goncurses"
+	"context"
+	"runtime/debug"
+	"sync"
 )
 
 const (
 	yOffset       = 1
 	xOffset       = 1
-	hightlightKey = nc.A_REVERSE
-
-	KeyEnter  = 10
-	KeyLeft   = nc.KEY_LEFT
-	KeyRight  = nc.KEY_RIGHT
-	KeyUp     = nc.KEY_UP
-	KeyDown   = nc.KEY_DOWN
-	KeyEscape = nc.KEY_ESC
+	hightlightKey = AttrReverse
+
+	KeyTab        Key = 9
+	KeyEnter      Key = 10
+	KeyEscape     Key = 27
+	KeyDown       Key = 258
+	KeyUp         Key = 259
+	KeyLeft       Key = 260
+	KeyRight      Key = 261
+	KeyHome       Key = 262
+	KeyF1         Key = 265
+	KeyF2         Key = 266
+	KeyF3         Key = 267
+	KeyF4         Key = 268
+	KeyF5         Key = 269
+	KeyF6         Key = 270
+	KeyF7         Key = 271
+	KeyF8         Key = 272
+	KeyF9         Key = 273
+	KeyF10        Key = 274
+	KeyF11        Key = 275
+	KeyF12        Key = 276
+	KeyShiftF1    Key = 277
+	KeyDelete     Key = 330
+	KeyInsert     Key = 331
+	KeyShiftDown  Key = 336
+	KeyShiftUp    Key = 337
+	KeyPageDown   Key = 338
+	KeyPageUp     Key = 339
+	KeyBackTab    Key = 353
+	KeyEnd        Key = 360
+	KeyShiftEnd   Key = 386
+	KeyShiftHome  Key = 391
+	KeyShiftLeft  Key = 393
+	KeyShiftRight Key = 402
+	KeyMouse      Key = 409
+	KeyResize     Key = 410
+
+	// Added to the key that is pressed together with alt
+	KeyAlt Key = 1 << 24
+	// Added to the arrow, home, end, page up or page down key that is pressed together with ctrl.
+	// Ctrl + letters are the control characters
+	KeyCtrl Key = 1 << 25
+	// Never pressed. Setting a key to KeyNone disables it
+	KeyNone Key = -1
 )
 
 type hasElementData interface {
@@ -25,7 +62,7 @@ type hasElementData interface {
 }
 
 type Drawable interface {
-	Draw(win *nc.Window, y, x int, attr ...nc.Char)
+	Draw(s Surface, y, x int, attr ...Attr)
 }
 
 type DrawableAsLine interface {
@@ -33,16 +70,57 @@ type DrawableAsLine interface {
 	Length() int
 }
 
+// Implemented by menus and elements that react to the resize of the window
+type Resizable interface {
+	// Called after the window is resized
+	OnResize(height, width int)
+}
+
 type Menu interface {
 	SetParent(window *Window)
+	// Draws the menu. The window refreshes the screen after the layers above the menu are drawn
 	Draw() error
-	HandleKey(key nc.Key) error
+	// Returns true if the key was handled. Unhandled keys go to the window
+	HandleKey(key Key) (bool, error)
 
 	AddElement(element UIElement)
 	GetElements() []UIElement
 	Focus(element hasElementData)
 }
 
+// Implemented by the menus that have their own key bindings
+type Bindable interface {
+	// Binds the key sequence to fn while the menu is shown
+	Bind(keys string, fn func() error) error
+	// Returns the key bindings of the menu
+	GetBindings() *KeyBindings
+}
+
+// Implemented by the menus that return the window they were added to
+type ParentGetter interface {
+	// Returns the window of the menu, nil if the menu wasn't added to a window
+	GetParent() *Window
+}
+
+// Implemented by the menus that are notified when they become the current menu of the running window
+type MenuEnterer interface {
+	// Called when the menu becomes the current menu of the running window
+	OnEnter() error
+}
+
+// Implemented by the menus that are notified when they stop being the current menu
+type MenuLeaver interface {
+	// Called when the menu stops being the current menu: it's popped, replaced or another menu is pushed above it.
+	// Also called when SetMenu drops the menu from the history
+	OnLeave() error
+}
+
+// Implemented by the menus that are notified when they become the current menu again
+type MenuResumer interface {
+	// Called when the menu becomes the current menu again after the menu above it is popped
+	OnResume() error
+}
+
 // The menu of the window
 type NormalMenu struct {
 	parent      *Window
@@ -50,6 +128,14 @@ type NormalMenu struct {
 	borderColor string
 	cctTitle    *CCTMessage
 	elements    []UIElement
+	bindings    *KeyBindings
+	quitKey     Key
+	spatialNav  bool
+	// The element that gets the mouse events until the button is released
+	mouseCapture UIElement
+	onEnter      func() error
+	onLeave      func() error
+	onResume     func() error
 }
 
 // Creates a menu
@@ -62,30 +148,31 @@ func NewNormalMenu(title string) (*NormalMenu, error) {
 		return nil, err
 	}
 	result.elements = []UIElement{}
+	result.bindings = NewKeyBindings()
+	result.quitKey = KeyEscape
 	result.borderColor = "normal"
 	return &result, nil
 }
 
-// Draws the menu
+// Draws the menu. The window refreshes the screen after the layers above the menu are drawn
 func (m NormalMenu) Draw() error {
-	m.parent.win.Erase()
+	screen := m.parent.screen
+	screen.Erase()
 	var err error
-	err = DrawBorders(m.parent.win, m.borderColor)
-	m.cctTitle.Draw(m.parent.win, 0, 1)
+	err = DrawBorders(screen, m.borderColor)
+	m.cctTitle.Draw(screen, 0, 1)
 	if err != nil {
 		return err
 	}
-	pWin := m.parent.win
-	for _, el := range m.elements {
-		elData := el.GetElementData()
-		if elData.Visible {
-			err = el.Draw(pWin)
+	m.arrange(m.parent.height, m.parent.width)
+	for _, el := range elementOrder(m.elements) {
+		if isShown(el) {
+			err = el.Draw(clippedSurface(screen, el))
 			if err != nil {
 				return err
 			}
 		}
 	}
-	m.parent.win.Refresh()
 	return nil
 }
 
@@ -94,6 +181,49 @@ func (m *NormalMenu) SetBorderColor(borderColor string) {
 	m.borderColor = borderColor
 }
 
+// Sets the key that requests the exit of the window (ESC by default). KeyNone disables it
+func (m *NormalMenu) SetQuitKey(key Key) {
+	m.quitKey = key
+}
+
+// Sets the function that is called when the menu becomes the current menu
+func (m *NormalMenu) SetOnEnter(fn func() error) {
+	m.onEnter = fn
+}
+
+// Sets the function that is called when the menu stops being the current menu
+func (m *NormalMenu) SetOnLeave(fn func() error) {
+	m.onLeave = fn
+}
+
+// Sets the function that is called when the menu becomes the current menu again after the menu above it is popped
+func (m *NormalMenu) SetOnResume(fn func() error) {
+	m.onResume = fn
+}
+
+// Calls the OnEnter hook
+func (m NormalMenu) OnEnter() error {
+	return callHook(m.onEnter)
+}
+
+// Calls the OnLeave hook
+func (m NormalMenu) OnLeave() error {
+	return callHook(m.onLeave)
+}
+
+// Calls the OnResume hook
+func (m NormalMenu) OnResume() error {
+	return callHook(m.onResume)
+}
+
+// Calls the hook if it's set
+func callHook(hook func() error) error {
+	if hook == nil {
+		return nil
+	}
+	return hook()
+}
+
 // Sets the title of the menu
 func (m *NormalMenu) SetTitle(title string) error {
 	var err error
@@ -101,65 +231,129 @@ func (m *NormalMenu) SetTitle(title string) error {
 	return err
 }
 
-// Returns the element that is located at the point
+// Returns the topmost visible element that is located at the point. The children of the containers are above the containers
 func (m NormalMenu) elementAt(y, x int) UIElement {
-	for _, el := range m.elements {
+	order := elementOrder(m.elements)
+	for i := len(order) - 1; i >= 0; i-- {
+		el := order[i]
 		elData := el.GetElementData()
-		if y >= elData.yPos && y <= elData.yPos+el.Height() && x >= elData.xPos && x <= elData.xPos+el.Width() {
+		if !isShown(el) || !inClipArea(el, y, x) {
+			continue
+		}
+		if y >= elData.yPos && y < elData.yPos+el.Height() && x >= elData.xPos && x < elData.xPos+el.Width() {
 			return el
 		}
 	}
 	return nil
 }
 
-// If esc is pressed, exits the application.
-// If mouse is clicked, focuses on the clicked element. If element is already focused, calls the HandleKey method in element.
-// Otherwise calls the HandleKey method in the focused element
-func (m *NormalMenu) HandleKey(key nc.Key) error {
-	if key == nc.KEY_ESC {
-		m.parent.Exit()
+// Sends the mouse event to the element under the pointer. A click focuses the element.
+// After a press the element gets the events until the button is released, even if the pointer leaves it
+func (m *NormalMenu) handleMouse(event MouseEvent) error {
+	captured := m.mouseCapture
+	m.mouseCapture = nil
+	element := captured
+	if element == nil || event.Action == MousePress {
+		element = m.elementAt(event.Y, event.X)
+	}
+	if element == nil {
 		return nil
 	}
-	// if key == nc.KEY_MOUSE {
-	// 	md := nc.GetMouse()
-	// 	element := w.elementAt(md.Y, md.X)
-	// 	elData := element.GetElementData()
-	// 	if element == nil {
-	// 		return nil
-	// 	}
-	// 	if elData.focused {
-	// 		// MessageBox(w, fmt.Sprintf("%v", elData), []string{})
-	// 		element.HandleKey(key)
-	// 	} else {
-	// 		w.Focus(element)
-	// 	}
-	// 	return nil
-	// }
-	for _, el := range m.elements {
-		elData := el.GetElementData()
-		if elData.focused {
-			switch key {
-			case elData.nextKey:
-				// focus on the elData.next
-				if elData.next == nil {
-					continue
-				}
-				elData.focused = false
-				elData.next.GetElementData().focused = true
-			case elData.prevKey:
-				// focus on the elData.prev
-				if elData.prev == nil {
-					continue
-				}
-				elData.focused = false
-				elData.prev.GetElementData().focused = true
-			default:
-				return el.HandleKey(key)
+	handler, ok := element.(MouseHandler)
+	if !ok || !element.GetElementData().Enabled {
+		return nil
+	}
+	pressed := event.Action == MousePress && event.Button != MouseWheelUp && event.Button != MouseWheelDown
+	if pressed || event.Action == MouseMove && captured != nil {
+		m.mouseCapture = element
+	}
+	if event.IsClick() && canFocus(element) {
+		m.Focus(element)
+	}
+	elData := element.GetElementData()
+	event.Y -= elData.yPos
+	event.X -= elData.xPos
+	return handler.HandleMouse(event)
+}
+
+// If the mouse is used, sends the event to the element under the pointer.
+// Otherwise the key goes to the bindings of the focused element, then to the element,
+// then to the bindings and to the containers of the element, then to the bindings of the menu.
+// If none of them handle the key, the menu moves the focus on the next/prev keys and requests the exit of the window on the quit key.
+// Returns false if the key wasn't handled
+func (m *NormalMenu) HandleKey(key Key) (bool, error) {
+	if key == KeyMouse {
+		return true, m.handleMouse(m.parent.GetMouse())
+	}
+	el := focusedElement(m)
+	if el != nil && el.GetElementData().Enabled {
+		handled, err := m.parent.callBinding(el.GetElementData().bindings, key)
+		if handled || err != nil {
+			return true, err
+		}
+		handled, err = el.HandleKey(key)
+		if handled || err != nil {
+			return true, err
+		}
+	}
+	if el != nil {
+		for c := el.GetElementData().container; c != nil; c = c.GetElementData().container {
+			handled, err := m.parent.callBinding(c.GetElementData().bindings, key)
+			if handled || err != nil {
+				return true, err
+			}
+			handled, err = c.HandleKey(key)
+			if handled || err != nil {
+				return true, err
 			}
-			break
 		}
 	}
-	return nil
+	handled, err := m.parent.callBinding(m.bindings, key)
+	if handled || err != nil {
+		return true, err
+	}
+	if handled, err := m.parent.goBack(key); handled || err != nil {
+		return true, err
+	}
+	if key == m.quitKey {
+		m.parent.RequestExit()
+		return true, nil
+	}
+	if m.spatialNav {
+		return m.navigate(el, key), nil
+	}
+	if el == nil {
+		return false, nil
+	}
+	elData := el.GetElementData()
+	var target UIElement
+	switch key {
+	case elData.nextKey:
+		target = linkTarget(el, true)
+	case elData.prevKey:
+		target = linkTarget(el, false)
+	}
+	if target == nil {
+		return false, nil
+	}
+	m.Focus(target)
+	return true, nil
+}
+
+// Applies the constraints of the elements and places the children of the containers
+func (m *NormalMenu) arrange(height, width int) {
+	applyConstraints(m.elements, height, width)
+	layoutElements(m.elements)
+}
+
+// Recalculates the layout and notifies the resizable elements about the resize
+func (m *NormalMenu) OnResize(height, width int) {
+	m.arrange(height, width)
+	for _, el := range m.elements {
+		if r, ok := el.(Resizable); ok {
+			r.OnResize(height, width)
+		}
+	}
 }
 
 // Returns the elements of the menu
@@ -179,10 +373,40 @@ func (m *NormalMenu) unfocusAll() {
 	}
 }
 
-// Unfocuses all the elements in the menu, then focuses the element
+// Unfocuses all the elements in the menu, then focuses the element. Focusing a container focuses its first focusable child.
+// If the focus moves to another element, calls the OnBlur hook of the previously focused element and the OnFocus hook of the element
 func (m *NormalMenu) Focus(element hasElementData) {
+	if el, ok := element.(UIElement); ok && !canFocus(el) {
+		if target := focusTarget(el, true); target != nil {
+			element = target
+		}
+	}
+	data := element.GetElementData()
+	var previous *UIElementData
+	if el := focusedElement(m); el != nil {
+		previous = el.GetElementData()
+	}
 	m.unfocusAll()
-	element.GetElementData().focused = true
+	data.focused = true
+	if previous == data {
+		return
+	}
+	if previous != nil && previous.onBlur != nil {
+		previous.onBlur()
+	}
+	if data.onFocus != nil {
+		data.onFocus()
+	}
+}
+
+// Binds the key sequence, like "ctrl+s" or "ctrl+x ctrl+c", to fn. The binding works while the menu is shown
+func (m *NormalMenu) Bind(keys string, fn func() error) error {
+	return m.bindings.Bind(keys, fn)
+}
+
+// Returns the key bindings of the menu
+func (m NormalMenu) GetBindings() *KeyBindings {
+	return m.bindings
 }
 
 // Sets the parent window of the menu
@@ -190,19 +414,29 @@ func (m *NormalMenu) SetParent(window *Window) {
 	m.parent = window
 }
 
+// Returns the window of the menu
+func (m NormalMenu) GetParent() *Window {
+	return m.parent
+}
+
 // A UI element
 type UIElement interface {
 	hasElementData
 
-	Draw(win *nc.Window) error
-	HandleKey(key nc.Key) error
+	Draw(s Surface) error
+	// Returns true if the key was handled. Unhandled keys go to the menu
+	HandleKey(key Key) (bool, error)
 	Height() int
 	Width() int
 }
 
-// Sets the location of the element
+// Sets the location of the element. The location of an element inside a panel is relative to the panel
 func SetYX(element hasElementData, y, x int) {
 	data := element.GetElementData()
+	if placer, ok := data.container.(childPlacer); ok {
+		placer.placeChild(data, y, x)
+		return
+	}
 	data.yPos = y + yOffset
 	data.xPos = x + xOffset
 }
@@ -218,27 +452,67 @@ func SetPrev(target hasElementData, element UIElement) {
 }
 
 // Sets the key for selecting the next element
-func SetNextKey(element hasElementData, key nc.Key) {
+func SetNextKey(element hasElementData, key Key) {
 	element.GetElementData().nextKey = key
 }
 
 // Sets the key for selecting the prev element
-func SetPrevKey(element hasElementData, key nc.Key) {
+func SetPrevKey(element hasElementData, key Key) {
 	element.GetElementData().prevKey = key
 }
 
+// Returns true if the element is focused
+func IsFocused(element hasElementData) bool {
+	return element.GetElementData().focused
+}
+
 // Toggles the visibility of the element
 func ToggleVisibility(element hasElementData, value bool) {
 	element.GetElementData().Visible = value
 }
 
+// Enables or disables the element. Disabled elements are drawn dimmed, can't be focused and don't handle keys and mouse events
+func SetEnabled(element hasElementData, value bool) {
+	element.GetElementData().Enabled = value
+}
+
+// Returns true if the element is enabled
+func IsEnabled(element hasElementData) bool {
+	return element.GetElementData().Enabled
+}
+
+// Sets the function that is called when the element gets the focus
+func OnFocus(element hasElementData, fn func()) {
+	element.GetElementData().onFocus = fn
+}
+
+// Sets the function that is called when the element loses the focus
+func OnBlur(element hasElementData, fn func()) {
+	element.GetElementData().onBlur = fn
+}
+
 // Element data. Describes the location, visibility and several keys of the element
 type UIElementData struct {
-	yPos, xPos       int
-	focused          bool
-	Visible          bool
+	yPos, xPos int
+	focused    bool
+	Visible    bool
+	// Disabled elements are drawn dimmed and can't be focused
+	Enabled bool
+	// Only visible, enabled and focusable elements get the focus from the navigation and the mouse
+	Focusable        bool
 	next, prev       UIElement
-	nextKey, prevKey nc.Key
+	nextKey, prevKey Key
+	bindings         *KeyBindings
+	onFocus, onBlur  func()
+	// The container that places the element, or nil
+	container UIElement
+	// The location and size of the element relative to the window, or nil
+	constraints *Constraints
+}
+
+// Returns true if the element should be drawn as focused
+func (d UIElementData) highlighted() bool {
+	return d.focused && d.Enabled
 }
 
 // Creates the element data
@@ -248,9 +522,11 @@ func createUIED(y, x int) *UIElementData {
 	result.xPos = x + xOffset
 	result.prev = nil
 	result.next = nil
-	result.prevKey = nc.KEY_UP
-	result.nextKey = nc.KEY_DOWN
+	result.prevKey = KeyUp
+	result.nextKey = KeyDown
 	result.Visible = true
+	result.Enabled = true
+	result.Focusable = true
 	return &result
 }
 
@@ -258,77 +534,212 @@ func createUIED(y, x int) *UIElementData {
 type Window struct {
 	height, width int
 	running       bool
+	exited        bool
+	inputDone     bool
+	resumed       bool
 	currentMenu   Menu
-	win           *nc.Window
+	screen        Screen
+	input         InputSource
+	keys          chan keyResult
+	mouse         MouseEvent
+	bindings      *KeyBindings
+	chord         []Key
+	layers        []windowLayer
+	history       []Menu
+	backKey       Key
+	updateLock    sync.Mutex
+	updates       []func()
+	wake          chan struct{}
+	timerLock     sync.Mutex
+	timers        map[*Timer]struct{}
+	endOnce       sync.Once
+	beforeExit    []func() bool
+	onExit        []func()
+	result        interface{}
 }
 
 // Returns the current menu of the window
-func (w Window) GetMenu() Menu {
+func (w *Window) GetMenu() Menu {
 	return w.currentMenu
 }
 
-// Sets the menu of the window
+// Sets the menu of the window and clears the history of the menus.
+// The errors of the OnLeave and OnEnter hooks are ignored, ResetMenu returns them
 func (w *Window) SetMenu(menu Menu) {
+	w.leaveAll()
+	w.history = nil
+	w.setCurrentMenu(menu)
+	w.enterMenu(menu, false)
+}
+
+// Makes the menu the current menu of the window
+func (w *Window) setCurrentMenu(menu Menu) {
 	menu.SetParent(w)
 	w.currentMenu = menu
+	w.chord = nil
+	if r, ok := menu.(Resizable); ok {
+		r.OnResize(w.height, w.width)
+	}
+}
+
+// Updates the size of the window and notifies the current menu and the layers
+func (w *Window) resize() {
+	w.height, w.width = w.screen.MaxYX()
+	if r, ok := w.currentMenu.(Resizable); ok {
+		r.OnResize(w.height, w.width)
+	}
+	for _, l := range w.layers {
+		if r, ok := l.layer.(Resizable); ok {
+			r.OnResize(w.height, w.width)
+		}
+	}
 }
 
 // Returns the height and width of the window
-func (w Window) GetMaxYX() (int, int) {
+func (w *Window) GetMaxYX() (int, int) {
 	return w.height, w.width
 }
 
-// Retunrs GetChar result
-func (w Window) GetKey() nc.Key {
-	return w.win.GetChar()
+// Returns the key read from the input source of the window. Queued updates are applied while waiting.
+// If the input source is exhausted, stops the window and returns KeyEscape
+func (w *Window) GetKey() Key {
+	key, _ := w.waitEvent(false)
+	return key
+}
+
+// Returns the mouse event of the last KeyMouse
+func (w *Window) GetMouse() MouseEvent {
+	return w.mouse
+}
+
+// Sets the input source of the window (should be called before Start). If source is nil, the keys are read from the screen
+func (w *Window) SetInput(source InputSource) {
+	w.inputDone = false
+	w.keys = nil
+	if source == nil {
+		source = screenInput{screen: w.screen}
+	}
+	w.input = source
 }
 
-// Returns the goncurses window
-func (w Window) GetWin() *nc.Window {
-	return w.win
+// Returns the screen of the window
+func (w *Window) GetScreen() Screen {
+	return w.screen
 }
 
-// Exits the window
+// Exits the window, stops all the timers. The BeforeExit hooks aren't called.
+// If the window is running, the screen is ended after Start returns, otherwise it is ended right away
 func (w *Window) Exit() {
+	running := w.running
 	w.running = false
-	nc.End()
+	w.markExited()
+	w.stopTimers()
+	if !running {
+		w.teardown()
+	}
 }
 
-// Basic goncurses configuration
-func (w *Window) config() {
-	// remove the delay from pressing the escape key
-	nc.SetEscDelay(0)
-	w.win.Keypad(true)
+// Exits the window with the result. The result is returned by Result after Start returns
+func (w *Window) ExitWith(result interface{}) {
+	w.result = result
+	w.Exit()
+}
 
-	nc.Raw(true)
-	nc.Echo(false)
-	nc.Cursor(0)
-	nc.CBreak(true)
-	nc.MouseInterval(50)
+// Returns the result the window exited with
+func (w *Window) Result() interface{} {
+	return w.result
+}
 
-	nc.MouseMask(nc.M_B1_PRESSED, nil) // only detect left mouse clicks
+// Calls the BeforeExit hooks, exits the window if none of them veto the exit.
+// Returns false if the exit was vetoed
+func (w *Window) RequestExit() bool {
+	for _, hook := range w.beforeExit {
+		if !hook() {
+			return false
+		}
+	}
+	w.Exit()
+	return true
 }
 
-// Starts the window
-func (w *Window) Start() error {
-	var err error
+// Adds the hook that is called before the window exits by the quit key or RequestExit.
+// If the hook returns false, the window keeps running
+func (w *Window) BeforeExit(hook func() bool) {
+	w.beforeExit = append(w.beforeExit, hook)
+}
+
+// Adds the hook that is called once when the window exits, before the screen is ended.
+// Dialogs can't be shown from the hook
+func (w *Window) OnExit(hook func()) {
+	w.onExit = append(w.onExit, hook)
+}
+
+// Marks the window as exited and calls the OnExit hooks. Does nothing after the first call
+func (w *Window) markExited() {
+	if w.exited {
+		return
+	}
+	w.exited = true
+	for _, hook := range w.onExit {
+		hook()
+	}
+}
+
+// Restores the cursor and ends the screen. Does nothing after the first call
+func (w *Window) teardown() {
+	w.endOnce.Do(func() {
+		w.markExited()
+		w.stopTimers()
+		w.screen.SetCursorVisible(true)
+		w.screen.End()
+	})
+}
+
+// Basic screen configuration
+func (w *Window) config() {
+	w.screen.SetCursorVisible(false)
+}
+
+// Starts the window. The screen is ended when Start returns.
+// The result the window exited with, see ExitWith, is available from Result after Start returns.
+// SIGINT requests the exit of the window, SIGTERM exits it, SIGTSTP suspends it.
+// If the window panics, the terminal is restored and the panic is returned as *PanicError
+func (w *Window) Start() (err error) {
 	w.running = true
+	w.config()
+	defer w.teardown()
+	defer w.watchSignals()()
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	err = w.enterMenu(w.currentMenu, false)
 	if err != nil {
 		return err
 	}
-	w.config()
-	defer nc.Cursor(1)
-	defer w.Exit()
-	var key nc.Key
+	var key Key
+	var isKey bool
 	for w.running {
 		// draw
-		err = w.currentMenu.Draw()
+		err = w.draw()
 		if err != nil {
 			return err
 		}
 		// handle key
-		key = w.GetKey()
-		err = w.currentMenu.HandleKey(key)
+		key, isKey = w.waitEvent(true)
+		if !isKey {
+			// updates were applied, redraw
+			continue
+		}
+		if w.inputDone {
+			break
+		}
+		if key == KeyResize {
+			w.resize()
+			continue
+		}
+		err = w.dispatchKey(key)
 		if err != nil {
 			return err
 		}
@@ -336,18 +747,54 @@ func (w *Window) Start() error {
 	return nil
 }
 
+// Starts the window. The window exits when the context is cancelled, then the error of the context is returned
+func (w *Window) StartContext(ctx context.Context) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			w.QueueUpdate(w.Exit)
+		case <-done:
+		}
+	}()
+	err := w.Start()
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
 // Creates new window (should only be called once)
 //
+// The window is drawn with goncurses, or with TermScreen if built with the termui_pure tag.
 // The menu of the window is of type NormalMenu
 func CreateWindow(title string) (*Window, error) {
+	screen, err := newDefaultScreen()
+	if err != nil {
+		return nil, err
+	}
+	return CreateWindowWithScreen(screen, title)
+}
+
+// Creates new window that is drawn on the screen
+//
+// The menu of the window is of type NormalMenu
+func CreateWindowWithScreen(screen Screen, title string) (*Window, error) {
 	var err error
 	result := Window{}
-	result.win, err = nc.Init()
+	result.screen = screen
+	result.wake = make(chan struct{}, 1)
+	result.timers = map[*Timer]struct{}{}
+	result.bindings = NewKeyBindings()
+	result.backKey = KeyNone
+	result.SetInput(nil)
+	err = initColors(screen)
 	if err != nil {
 		return nil, err
 	}
-	initColors()
 	result.running = false
+	result.height, result.width = screen.MaxYX()
 	result.currentMenu, err = NewNormalMenu(title)
 	if err != nil {
 		return nil, err
@@ -377,12 +824,16 @@ func Link(elements ...UIElement) {
 	}
 }
 
-// Calls the goncurses Flash method
+// Flashes the screen of the window
 func Flash() {
-	nc.Flash()
+	if activeScreen != nil {
+		activeScreen.Flash()
+	}
 }
 
-// Calls the goncurses Beep method
+// Beeps on the screen of the window
 func Beep() {
-	nc.Beep()
+	if activeScreen != nil {
+		activeScreen.Beep()
+	}
 }
@@ -7,8 +7,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-
-	nc "github.com/rthornton128/goncurses"
+	"unicode/utf8"
 )
 
 // cct format example:
@@ -16,35 +15,46 @@ import (
 
 const (
 	rawcctregex = `\$\{([\w|-]+)\}([^\$]*)`
+
+	colorBlack   = 0
+	colorRed     = 1
+	colorGreen   = 2
+	colorYellow  = 3
+	colorBlue    = 4
+	colorMagenta = 5
+	colorCyan    = 6
+	colorWhite   = 7
 )
 
 var (
 	cctregex = regexp.MustCompile(rawcctregex)
 
 	colors = map[string]int16{
-		"red":     nc.C_RED,
-		"blue":    nc.C_BLUE,
-		"green":   nc.C_GREEN,
-		"black":   nc.C_BLACK,
-		"yellow":  nc.C_YELLOW,
-		"cyan":    nc.C_CYAN,
-		"magenta": nc.C_MAGENTA,
-		"white":   nc.C_WHITE,
+		"red":     colorRed,
+		"blue":    colorBlue,
+		"green":   colorGreen,
+		"black":   colorBlack,
+		"yellow":  colorYellow,
+		"cyan":    colorCyan,
+		"magenta": colorMagenta,
+		"white":   colorWhite,
 		"gray":    245,
 		"pink":    219,
 		"orange":  202,
 	}
-	colorMap = map[string]nc.Char{}
+	colorMap = map[string]Attr{}
+	// Initialized color pairs. The pair index is the index + 1
+	colorPairs = [][2]int16{}
 )
 
 // CCT message (curses color text)
 type CCTMessage struct {
 	strings []string
-	colors  []nc.Char
+	colors  []Attr
 }
 
 // Returns the pair at i
-func (m CCTMessage) pair(i int) (string, nc.Char) {
+func (m CCTMessage) pair(i int) (string, Attr) {
 	return m.strings[i], m.colors[i]
 }
 
@@ -53,11 +63,11 @@ func (m CCTMessage) pairCount() int {
 	return len(m.strings)
 }
 
-// Returns the actual length of the message
+// Returns the actual length of the message, every rune takes a cell
 func (m CCTMessage) Length() int {
 	result := 0
 	for _, s := range m.strings {
-		result += len(s)
+		result += utf8.RuneCountInString(s)
 	}
 	return result
 }
@@ -65,7 +75,7 @@ func (m CCTMessage) Length() int {
 // Converts the cct string to string format
 func (m CCTMessage) ToString() string {
 	result := ""
-	reverseColorMap := map[nc.Char]string{}
+	reverseColorMap := map[Attr]string{}
 	for key, value := range colorMap {
 		reverseColorMap[value] = key
 	}
@@ -85,16 +95,16 @@ func (m CCTMessage) ToRawString() string {
 }
 
 // Draws the CCTMessage
-func (m CCTMessage) Draw(win *nc.Window, y, x int, attr ...nc.Char) {
+func (m CCTMessage) Draw(s Surface, y, x int, attr ...Attr) {
 	for i := 0; i < m.pairCount(); i++ {
-		s, color := m.pair(i)
-		Put(win, y, x, s, append(attr, color)...)
-		x += len(s)
+		line, color := m.pair(i)
+		Put(s, y, x, line, append(attr, color)...)
+		x += utf8.RuneCountInString(line)
 	}
 }
 
 // Parses the colors. If colorPair doesn't exist yet, initializes it
-func ParseColorPair(colorPair string) (nc.Char, error) {
+func ParseColorPair(colorPair string) (Attr, error) {
 	originalColorPair := colorPair
 	if !strings.ContainsRune(colorPair, '-') {
 		colorPair += "-normal"
@@ -128,12 +138,15 @@ func ParseColorPair(colorPair string) (nc.Char, error) {
 			return 0, fmt.Errorf("termui - can't recognize color %v in color pair %v", bg, colorPair)
 		}
 	}
-	pairI := int16(len(colorMap) + 1)
-	err := nc.InitPair(pairI, fgres, bgres)
-	if err != nil {
-		return 0, err
+	pairI := int16(len(colorPairs) + 1)
+	if activeScreen != nil {
+		err := activeScreen.InitPair(pairI, fgres, bgres)
+		if err != nil {
+			return 0, err
+		}
 	}
-	result = nc.ColorPair(pairI)
+	colorPairs = append(colorPairs, [2]int16{fgres, bgres})
+	result = colorPairAttr(pairI)
 	colorMap[colorPair] = result
 	return result, nil
 }
@@ -149,7 +162,7 @@ func ToCCTMessage(line string) (*CCTMessage, error) {
 	result := CCTMessage{}
 	matches := cctregex.FindAllStringSubmatch(line, -1)
 	result.strings = make([]string, 0, len(matches))
-	result.colors = make([]nc.Char, 0, len(matches))
+	result.colors = make([]Attr, 0, len(matches))
 	for _, match := range matches {
 		colorPair := match[1]
 		s := match[2]
@@ -183,15 +196,14 @@ func init() {
 	}
 }
 
-// Initializes the colors
-func initColors() {
-	err := nc.StartColor()
-	// nc.Flash()
-	if err != nil {
-		panic(err)
-	}
-	err = nc.UseDefaultColors()
-	if err != nil {
-		panic(err)
+// Makes the screen active and initializes all the parsed color pairs on it
+func initColors(screen Screen) error {
+	activeScreen = screen
+	for i, pair := range colorPairs {
+		err := screen.InitPair(int16(i+1), pair[0], pair[1])
+		if err != nil {
+			return err
+		}
 	}
+	return nil
 }
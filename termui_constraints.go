@@ -0,0 +1,134 @@
+package termui
+
+type sizeKind int
+
+const (
+	sizeAuto sizeKind = iota
+	sizeCells
+	sizePercent
+	sizeFraction
+	sizeFill
+)
+
+// A length relative to the area inside the borders of the menu. The zero Size keeps the length of the element
+type Size struct {
+	kind     sizeKind
+	num, den int
+	min, max int
+}
+
+// Returns the size of n cells
+func Cells(n int) Size {
+	return Size{kind: sizeCells, num: n}
+}
+
+// Returns the size of percent % of the area
+func Percent(percent int) Size {
+	return Size{kind: sizePercent, num: percent, den: 100}
+}
+
+// Returns the size of num/den of the area
+func Fraction(num, den int) Size {
+	return Size{kind: sizeFraction, num: num, den: den}
+}
+
+// Returns the size that fills the rest of the area.
+// A height or width fills the area from the element to the border, a location puts the element against the bottom or the right border
+func Fill() Size {
+	return Size{kind: sizeFill}
+}
+
+// Returns the size that is at least n cells
+func (s Size) Min(n int) Size {
+	s.min = n
+	return s
+}
+
+// Returns the size that is at most n cells
+func (s Size) Max(n int) Size {
+	s.max = n
+	return s
+}
+
+// Returns the length in cells. Total is the length of the area, remaining is the length that is left for Fill
+func (s Size) resolve(total, remaining int) int {
+	result := 0
+	switch s.kind {
+	case sizeCells:
+		result = s.num
+	case sizePercent, sizeFraction:
+		if s.den != 0 {
+			result = total * s.num / s.den
+		}
+	case sizeFill:
+		result = remaining
+	}
+	if s.max > 0 && result > s.max {
+		result = s.max
+	}
+	if result < s.min {
+		result = s.min
+	}
+	return result
+}
+
+// The location and the size of an element relative to the area inside the borders of the menu.
+// Unset fields keep the values of the element. The location of the elements inside containers is set by the containers
+type Constraints struct {
+	Y, X          Size
+	Height, Width Size
+}
+
+// An element that can be resized by its constraints
+type Sizable interface {
+	// Sets the height and width of the element. Elements may ignore one of them
+	SetSize(height, width int)
+}
+
+// Sets the constraints of the element. They are applied every time the menu is drawn or resized
+func SetConstraints(element hasElementData, constraints Constraints) {
+	element.GetElementData().constraints = &constraints
+}
+
+// Moves the element to the location of its constraints
+func placeConstrained(element UIElement, areaHeight, areaWidth int) {
+	data := element.GetElementData()
+	c := data.constraints
+	if data.container != nil {
+		return
+	}
+	if c.Y.kind != sizeAuto {
+		data.yPos = c.Y.resolve(areaHeight, areaHeight-element.Height()) + yOffset
+	}
+	if c.X.kind != sizeAuto {
+		data.xPos = c.X.resolve(areaWidth, areaWidth-element.Width()) + xOffset
+	}
+}
+
+// Applies the constraints of the elements. Height and width are the size of the window
+func applyConstraints(elements []UIElement, height, width int) {
+	areaHeight := height - yOffset*2
+	areaWidth := width - xOffset*2
+	for _, el := range elements {
+		data := el.GetElementData()
+		c := data.constraints
+		if c == nil {
+			continue
+		}
+		placeConstrained(el, areaHeight, areaWidth)
+		sizable, ok := el.(Sizable)
+		if !ok || (c.Height.kind == sizeAuto && c.Width.kind == sizeAuto) {
+			continue
+		}
+		newHeight, newWidth := el.Height(), el.Width()
+		if c.Height.kind != sizeAuto {
+			newHeight = c.Height.resolve(areaHeight, areaHeight-(data.yPos-yOffset))
+		}
+		if c.Width.kind != sizeAuto {
+			newWidth = c.Width.resolve(areaWidth, areaWidth-(data.xPos-xOffset))
+		}
+		sizable.SetSize(newHeight, newWidth)
+		// the elements against the bottom or the right border depend on the new size
+		placeConstrained(el, areaHeight, areaWidth)
+	}
+}
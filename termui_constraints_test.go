@@ -0,0 +1,54 @@
+package termui
+
+import "testing"
+
+func TestSizeResolve(t *testing.T) {
+	tests := []struct {
+		name             string
+		size             Size
+		total, remaining int
+		want             int
+	}{
+		{"auto", Size{}, 80, 40, 0},
+		{"cells", Cells(5), 80, 40, 5},
+		{"percent", Percent(50), 81, 40, 40},
+		{"fraction", Fraction(1, 3), 90, 40, 30},
+		{"empty fraction", Fraction(1, 0), 90, 40, 0},
+		{"fill", Fill(), 80, 40, 40},
+		{"min", Percent(10).Min(20), 80, 40, 20},
+		{"max", Fill().Max(10), 80, 40, 10},
+		{"min over max", Cells(5).Min(8).Max(6), 80, 40, 8},
+	}
+	for _, tt := range tests {
+		if got := tt.size.resolve(tt.total, tt.remaining); got != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConstraintsFollowResize(t *testing.T) {
+	w, screen := newTestWindow(t, 6, 20)
+	menu := w.GetMenu()
+	bar, err := NewProgressBar(menu, 0, 0, 5, 10, false, "green", "normal")
+	must(t, err)
+	bar.Set(5)
+	SetConstraints(bar, Constraints{Y: Fill(), X: Cells(1), Width: Fill().Max(30)})
+	label, err := NewLabel(menu, 0, 0, "right")
+	must(t, err)
+	SetConstraints(label, Constraints{X: Fill(), Y: Percent(50)})
+	matchGolden(t, w, screen, "constraints")
+	expectYX(t, "bar", bar, 3, 1)
+	expectYX(t, "label", label, 2, 13)
+	if bar.Width() != 17 {
+		t.Fatalf("bar is %v wide, want 17", bar.Width())
+	}
+
+	screen.Resize(10, 40)
+	w.resize()
+	matchGolden(t, w, screen, "constraints_resized")
+	expectYX(t, "bar", bar, 7, 1)
+	expectYX(t, "label", label, 4, 33)
+	if bar.Width() != 30 {
+		t.Fatalf("bar is %v wide, want 30", bar.Width())
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"math"
 	"strconv"
-
-	nc "github.com/rthornton128/goncurses"
 )
 
 const (
@@ -28,20 +26,21 @@ func NewLabel(menu Menu, y, x int, text string) (*Label, error) {
 		return nil, err
 	}
 	result.data = createUIED(y, x)
+	result.data.Focusable = false
 	menu.AddElement(&result)
 	return &result, nil
 }
 
 // Draws the label
-func (l Label) Draw(win *nc.Window) error {
-	l.cctText.Draw(win, l.data.yPos, l.data.xPos)
+func (l Label) Draw(s Surface) error {
+	l.cctText.Draw(s, l.data.yPos, l.data.xPos)
 	// put(pWin, data.yPos, data.xPos, l.text, attr)
 	return nil
 }
 
-// Doesn't do anything
-func (l Label) HandleKey(key nc.Key) error {
-	return nil
+// Doesn't handle any keys
+func (l Label) HandleKey(key Key) (bool, error) {
+	return false, nil
 }
 
 // Sets the text of the label
@@ -69,7 +68,7 @@ func (l Label) Width() int {
 // A separator element
 type Separator struct {
 	data   *UIElementData
-	bcolor nc.Char
+	bcolor Attr
 }
 
 // Creates a separator
@@ -77,6 +76,7 @@ func NewSeparator(menu Menu, y int, borderColor string) (*Separator, error) {
 	result := Separator{}
 	var err error
 	result.data = createUIED(y, 0)
+	result.data.Focusable = false
 	result.bcolor, err = ParseColorPair(borderColor)
 	if err != nil {
 		return nil, err
@@ -91,21 +91,19 @@ func (s Separator) GetElementData() *UIElementData {
 }
 
 // Draws the separator
-func (s Separator) Draw(win *nc.Window) error {
-	_, width := win.MaxYX()
-	win.AttrOn(s.bcolor)
-	win.MoveAddChar(s.data.yPos, 0, nc.ACS_LTEE)
+func (s Separator) Draw(surface Surface) error {
+	_, width := surface.MaxYX()
+	surface.SetCell(s.data.yPos, 0, runeLTee, s.bcolor)
 	for i := 1; i < width-1; i++ {
-		win.MoveAddChar(s.data.yPos, i, nc.ACS_HLINE)
+		surface.SetCell(s.data.yPos, i, runeHLine, s.bcolor)
 	}
-	win.MoveAddChar(s.data.yPos, width-1, nc.ACS_RTEE)
-	win.AttrOff(s.bcolor)
+	surface.SetCell(s.data.yPos, width-1, runeRTee, s.bcolor)
 	return nil
 }
 
-// Doesn't do anything
-func (s Separator) HandleKey(key nc.Key) error {
-	return nil
+// Doesn't handle any keys
+func (s Separator) HandleKey(key Key) (bool, error) {
+	return false, nil
 }
 
 // Returns 1
@@ -121,13 +119,13 @@ func (s Separator) Width() int {
 // A clickable button element
 type Button struct {
 	click    func() error
-	clickKey nc.Key
+	clickKey Key
 	data     *UIElementData
 	cctText  *CCTMessage
 }
 
 // Creates a new button
-func NewButton(menu Menu, y, x int, text string, click func() error, clickKey nc.Key) (*Button, error) {
+func NewButton(menu Menu, y, x int, text string, click func() error, clickKey Key) (*Button, error) {
 	result := Button{}
 	err := result.SetText(text)
 	if err != nil {
@@ -140,13 +138,14 @@ func NewButton(menu Menu, y, x int, text string, click func() error, clickKey nc
 	return &result, nil
 }
 
-// Draws the button
-func (b Button) Draw(win *nc.Window) error {
-	attr := nc.A_NORMAL
-	if b.data.focused {
+// Draws the button. A disabled button is dimmed
+func (b Button) Draw(s Surface) error {
+	s = elementSurface(s, b.data)
+	attr := AttrNormal
+	if b.data.highlighted() {
 		attr = hightlightKey
 	}
-	b.cctText.Draw(win, b.data.yPos, b.data.xPos, attr)
+	b.cctText.Draw(s, b.data.yPos, b.data.xPos, attr)
 	return nil
 }
 
@@ -162,14 +161,19 @@ func (b Button) GetText() string {
 	return b.cctText.ToString()
 }
 
-// On ENTER or mouse click calls click
-func (b Button) HandleKey(key nc.Key) error {
+// On ENTER calls click
+func (b Button) HandleKey(key Key) (bool, error) {
 	if key == b.clickKey {
+		return true, b.click()
+	}
+	return false, nil
+}
+
+// On mouse click calls click
+func (b Button) HandleMouse(event MouseEvent) error {
+	if event.IsClick() {
 		return b.click()
 	}
-	// if key == nc.KEY_MOUSE {
-	// 	return b.click()
-	// }
 	return nil
 }
 
@@ -196,7 +200,7 @@ type PieChart struct {
 	height int
 	width  int
 	bcolor string
-	colors []nc.Char
+	colors []Attr
 	data   *UIElementData
 }
 
@@ -208,12 +212,13 @@ func NewPieChart(menu Menu, y, x, height, width int, values []int, colorPairs []
 	result.width = width
 	result.total = 0
 	result.data = createUIED(y, x)
+	result.data.Focusable = false
 
 	result.bcolor = borderColor
 	result.SetValues(values)
 	if len(colorPairs) == 0 {
 		// create custom colors
-		result.colors = make([]nc.Char, 0, len(values))
+		result.colors = make([]Attr, 0, len(values))
 		for i := startingColor; i < len(values)*colorStep+startingColor; i += colorStep {
 			pair := strconv.Itoa(i) + "-normal"
 			color, err := ParseColorPair(pair)
@@ -238,25 +243,22 @@ func (p PieChart) GetElementData() *UIElementData {
 }
 
 // Draws the pie chart
-func (p PieChart) Draw(win *nc.Window) error {
-	var err error
+func (p PieChart) Draw(s Surface) error {
 	yPos := p.data.yPos
 	xPos := p.data.xPos
 	centerY := p.height/2 + yPos
 	centerX := p.width/2 + xPos
 	radius := MinInt(p.height/2, p.width/2) - 1
-	DrawBox(win, yPos, xPos, p.height, p.width, p.bcolor)
-	win.MovePrintf(yPos, xPos, "%v", p.values)
+	DrawBox(s, yPos, xPos, p.height, p.width, p.bcolor)
+	Put(s, yPos, xPos, fmt.Sprintf("%v", p.values))
 	for i := 0; i < p.height; i++ {
 		for j := 0; j < p.width; j++ {
 			y := yPos + i
 			x := xPos + j
 			distance := math.Sqrt(math.Pow(float64(centerY-y), 2) + math.Pow(float64(centerX-x)/2, 2))
 			if distance < float64(radius) {
-				win.MoveAddChar(y, x, 'a')
-				// continue
 				if p.total == 0 {
-					win.MoveAddChar(y, x, nc.ACS_BLOCK)
+					s.SetCell(y, x, runeBlock, AttrNormal)
 					continue
 				}
 				top := (y - centerY) * 2
@@ -269,24 +271,16 @@ func (p PieChart) Draw(win *nc.Window) error {
 						break
 					}
 				}
-				err = win.AttrOn(p.colors[ri])
-				if err != nil {
-					return err
-				}
-				win.MoveAddChar(y, x, nc.ACS_BLOCK)
-				err = win.AttrOff(p.colors[ri])
-				if err != nil {
-					return err
-				}
+				s.SetCell(y, x, runeBlock, p.colors[ri])
 			}
 		}
 	}
 	return nil
 }
 
-// Doens't do anything
-func (p PieChart) HandleKey(key nc.Key) error {
-	return nil
+// Doesn't handle any keys
+func (p PieChart) HandleKey(key Key) (bool, error) {
+	return false, nil
 }
 
 // Returns the height of the pie chart
@@ -299,6 +293,12 @@ func (p PieChart) Width() int {
 	return p.width
 }
 
+// Sets the height and width of the pie chart
+func (p *PieChart) SetSize(height, width int) {
+	p.height = MaxInt(height, 2)
+	p.width = MaxInt(width, 2)
+}
+
 // Set the values of the pie chart
 func (p *PieChart) SetValues(values []int) {
 	p.total = SumInt(values...)
@@ -321,7 +321,7 @@ func (p *PieChart) setColors(colorPairs []string) error {
 	if len(p.values) != len(colorPairs) {
 		return fmt.Errorf("termui - amount of colors and values has to be the same for PieChart (v: %v, c: %v)", len(p.values), len(colorPairs))
 	}
-	p.colors = make([]nc.Char, 0, len(colorPairs))
+	p.colors = make([]Attr, 0, len(colorPairs))
 	for _, colorPair := range colorPairs {
 		color, err := ParseColorPair(colorPair)
 		if err != nil {
@@ -336,8 +336,8 @@ func (p *PieChart) setColors(colorPairs []string) error {
 type WordChoice struct {
 	wct    *WordChoiceTemplate
 	data   *UIElementData
-	IncKey nc.Key
-	DecKey nc.Key
+	IncKey Key
+	DecKey Key
 }
 
 // Creates a word choice element
@@ -365,9 +365,10 @@ func (w WordChoice) GetSelected() *CCTMessage {
 	return w.wct.GetSelected()
 }
 
-// Draws the WordChoice element
-func (w WordChoice) Draw(win *nc.Window) error {
-	return w.wct.Draw(win, w.data.yPos, w.data.xPos, w.data.focused)
+// Draws the WordChoice element. A disabled element is dimmed
+func (w WordChoice) Draw(s Surface) error {
+	s = elementSurface(s, w.data)
+	return w.wct.Draw(s, w.data.yPos, w.data.xPos, w.data.highlighted())
 }
 
 // Returns the element data of the element
@@ -376,12 +377,25 @@ func (w WordChoice) GetElementData() *UIElementData {
 }
 
 // Toggles between the options
-func (w WordChoice) HandleKey(key nc.Key) error {
+func (w WordChoice) HandleKey(key Key) (bool, error) {
 	switch key {
 	case KeyRight:
 		w.wct.FocusNext()
 	case KeyLeft:
 		w.wct.FocusPrev()
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// On click on the arrows or on the mouse wheel toggles between the options
+func (w WordChoice) HandleMouse(event MouseEvent) error {
+	switch {
+	case event.Button == MouseWheelUp, event.IsClick() && event.X == 0:
+		w.wct.FocusPrev()
+	case event.Button == MouseWheelDown, event.IsClick() && event.X == w.wct.maxLen+1:
+		w.wct.FocusNext()
 	}
 	return nil
 }
@@ -393,14 +407,14 @@ func (w WordChoice) Height() int {
 
 // Returns the length of the longest option + 2
 func (w WordChoice) Width() int {
-	return w.wct.maxLen
+	return w.wct.maxLen + 2
 }
 
 // A line edit element
 type LineEdit struct {
 	let    *LineEditTemplate
 	data   *UIElementData
-	tcolor nc.Char
+	tcolor Attr
 }
 
 // Creates a new line edit element
@@ -432,26 +446,36 @@ func (l LineEdit) GetElementData() *UIElementData {
 	return l.data
 }
 
-// Draws the element
-func (l LineEdit) Draw(win *nc.Window) error {
-	win.AttrOn(l.tcolor)
-	defer win.AttrOff(l.tcolor)
-	return l.let.Draw(win, l.data.yPos, l.data.xPos, l.data.focused)
+// Draws the element. A disabled element is dimmed
+func (l LineEdit) Draw(s Surface) error {
+	s = elementSurface(s, l.data)
+	return l.let.Draw(s, l.data.yPos, l.data.xPos, l.data.highlighted(), l.tcolor)
 }
 
 // On left/right moves the cursor.
 // On letters and some other characters enters them.
 // On backspace removes the current character.
-func (l LineEdit) HandleKey(key nc.Key) error {
-	switch key {
-	case KeyLeft:
+// Other keys aren't handled
+func (l LineEdit) HandleKey(key Key) (bool, error) {
+	switch {
+	case key == KeyLeft:
 		l.let.MoveCursorLeft()
-	case KeyRight:
+	case key == KeyRight:
 		l.let.MoveCursorRight()
-	case KeyBackspace:
+	case key == KeyBackspace:
 		l.let.DeleteSelected()
-	default:
+	case isValidLineEditCh(rune(key)):
 		l.let.AddCh(rune(key))
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// On click moves the cursor to the clicked character
+func (l LineEdit) HandleMouse(event MouseEvent) error {
+	if event.IsClick() {
+		l.let.MoveCursorTo(event.X)
 	}
 	return nil
 }
@@ -473,9 +497,9 @@ type List struct {
 	bcolor        string
 	maxWidth      int
 	click         func(choice, cursor int, option DrawableAsLine) error
-	scrollUpKey   nc.Key
-	scrollDownKey nc.Key
-	clickKey      nc.Key
+	scrollUpKey   Key
+	scrollDownKey Key
+	clickKey      Key
 }
 
 // Creates a list element
@@ -519,7 +543,7 @@ func (l List) GetElementData() *UIElementData {
 }
 
 // Draws the scroller
-func (l List) drawScroller(win *nc.Window) error {
+func (l List) drawScroller(s Surface) error {
 	if len(l.lt.options) > l.lt.maxDisplayAmount {
 		y := l.data.yPos
 		x := l.data.xPos
@@ -527,15 +551,15 @@ func (l List) drawScroller(win *nc.Window) error {
 		width := l.Width()
 		// draw the arrows
 		if l.lt.pageN != 0 {
-			win.MoveAddChar(1+y, l.Width()-2+x, nc.ACS_UARROW)
+			s.SetCell(1+y, l.Width()-2+x, runeUArrow, AttrNormal)
 		}
 		if l.lt.pageN != len(l.lt.options)-l.lt.maxDisplayAmount {
-			win.MoveAddChar(height-2+y, width-2+x, nc.ACS_DARROW)
+			s.SetCell(height-2+y, width-2+x, runeDArrow, AttrNormal)
 		}
 		// draw the line
 		scrollerL := height - 4
 		for i := 0; i < scrollerL; i++ {
-			win.MoveAddChar(2+y+i, width-2+x, nc.ACS_VLINE)
+			s.SetCell(2+y+i, width-2+x, runeVLine, AttrNormal)
 		}
 		// draw the scroller
 		sbHeight := l.lt.maxDisplayAmount*scrollerL/len(l.lt.options) + 1
@@ -547,28 +571,27 @@ func (l List) drawScroller(win *nc.Window) error {
 		if err != nil {
 			return err
 		}
-		win.AttrOn(color)
 		for i := 0; i < sbHeight; i++ {
-			win.MoveAddChar(2+y+i+sbOffset, width-2+x, ' ')
+			s.SetCell(2+y+i+sbOffset, width-2+x, ' ', color)
 		}
-		win.AttrOff(color)
 	}
 	return nil
 }
 
-// Draws the list
-func (l List) Draw(win *nc.Window) error {
+// Draws the list. A disabled list is dimmed
+func (l List) Draw(s Surface) error {
 	var err error
-	DrawBox(win, l.data.yPos, l.data.xPos, l.Height(), l.Width(), l.bcolor)
-	err = l.drawScroller(win)
+	s = elementSurface(s, l.data)
+	DrawBox(s, l.data.yPos, l.data.xPos, l.Height(), l.Width(), l.bcolor)
+	err = l.drawScroller(s)
 	if err != nil {
 		return err
 	}
-	return l.lt.Draw(win, l.data.yPos+1, l.data.xPos+1, l.data.focused)
+	return l.lt.Draw(s, l.data.yPos+1, l.data.xPos+1, l.data.highlighted())
 }
 
-// On scroll keys scrolls the list
-func (l List) HandleKey(key nc.Key) error {
+// On scroll keys scrolls the list, on the click key calls click
+func (l List) HandleKey(key Key) (bool, error) {
 	switch key {
 	case l.scrollDownKey:
 		l.lt.ScrollDown()
@@ -576,8 +599,29 @@ func (l List) HandleKey(key nc.Key) error {
 		l.lt.ScrollUp()
 	case l.clickKey:
 		if len(l.lt.options) > 0 {
-			return l.click(l.lt.choice, l.lt.cursor, l.lt.options[l.lt.choice])
+			return true, l.click(l.lt.choice, l.lt.cursor, l.lt.options[l.lt.choice])
+		}
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// On mouse wheel scrolls the list.
+// On click selects the clicked option, if the option is already selected calls click
+func (l List) HandleMouse(event MouseEvent) error {
+	switch {
+	case event.Button == MouseWheelUp:
+		l.lt.ScrollUp()
+	case event.Button == MouseWheelDown:
+		l.lt.ScrollDown()
+	case event.IsClick():
+		row := event.Y - 1
+		if row == l.lt.cursor {
+			_, err := l.HandleKey(l.clickKey)
+			return err
 		}
+		l.lt.SelectRow(row)
 	}
 	return nil
 }
@@ -592,6 +636,11 @@ func (l List) Width() int {
 	return l.maxWidth + 4
 }
 
+// Sets the amount of the displayed options to fit the height. The width is set by the options
+func (l *List) SetSize(height, width int) {
+	l.lt.SetMaxDisplayAmount(height - 2)
+}
+
 // A progress bar element
 type ProgressBar struct {
 	data *UIElementData
@@ -602,6 +651,7 @@ type ProgressBar struct {
 func NewProgressBar(menu Menu, y, x, barLength, max int, showInfo bool, barColor string, infoColor string) (*ProgressBar, error) {
 	result := ProgressBar{}
 	result.data = createUIED(y, x)
+	result.data.Focusable = false
 	var err error
 	result.pbt, err = CreateProgressBarTemplate(barLength, max, showInfo, barColor, infoColor)
 	if err != nil {
@@ -622,13 +672,13 @@ func (p ProgressBar) GetElementData() *UIElementData {
 }
 
 // Draws the element
-func (p ProgressBar) Draw(win *nc.Window) error {
-	return p.pbt.Draw(win, p.data.yPos, p.data.xPos)
+func (p ProgressBar) Draw(s Surface) error {
+	return p.pbt.Draw(s, p.data.yPos, p.data.xPos)
 }
 
-// Doesn't do anthing
-func (p ProgressBar) HandleKey(key nc.Key) error {
-	return nil
+// Doesn't handle any keys
+func (p ProgressBar) HandleKey(key Key) (bool, error) {
+	return false, nil
 }
 
 // Returns 1
@@ -640,3 +690,8 @@ func (p ProgressBar) Height() int {
 func (p ProgressBar) Width() int {
 	return len(p.pbt.clears)
 }
+
+// Sets the length of the bar to fit the width. The height is always 1
+func (p *ProgressBar) SetSize(height, width int) {
+	p.pbt.SetBarLength(width - len(p.pbt.clears) + p.pbt.barLength)
+}
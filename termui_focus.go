@@ -0,0 +1,208 @@
+package termui
+
+const (
+	// Added to the distance of the elements that are more across than along the navigation direction
+	outOfConeDistance = 1 << 16
+)
+
+// Returns true if the element can be focused
+func canFocus(element UIElement) bool {
+	data := element.GetElementData()
+	return data.Focusable && data.Enabled && isShown(element)
+}
+
+// Returns the first (or last) focusable child if the element is a container, otherwise the element if it can be focused, or nil
+func focusTarget(element UIElement, first bool) UIElement {
+	if c, ok := element.(Container); ok && isShown(element) {
+		children := c.GetChildren()
+		for i := range children {
+			child := children[i]
+			if !first {
+				child = children[len(children)-1-i]
+			}
+			if target := focusTarget(child, first); target != nil {
+				return target
+			}
+		}
+	}
+	if canFocus(element) {
+		return element
+	}
+	return nil
+}
+
+// Returns the next (or prev) link of the element
+func linkOf(element UIElement, next bool) UIElement {
+	if next {
+		return element.GetElementData().next
+	}
+	return element.GetElementData().prev
+}
+
+// Returns the first element that can be focused in the chain of the next (or prev) links of the element.
+// Links to containers lead to their children, elements without links inside containers continue with the links of the containers.
+// Returns nil if there is none
+func linkTarget(element UIElement, next bool) UIElement {
+	visited := map[UIElement]bool{element: true}
+	current := element
+	for {
+		link := linkOf(current, next)
+		for link == nil && current.GetElementData().container != nil {
+			current = current.GetElementData().container
+			link = linkOf(current, next)
+		}
+		if link == nil || visited[link] {
+			return nil
+		}
+		visited[link] = true
+		if target := focusTarget(link, next); target != nil {
+			return target
+		}
+		current = link
+	}
+}
+
+// Returns the elements of the menu with every container followed by its children.
+// The elements are drawn and traversed by the focus in this order, so the containers are drawn below their children
+func elementOrder(elements []UIElement) []UIElement {
+	result := []UIElement{}
+	var add func(element UIElement)
+	add = func(element UIElement) {
+		result = append(result, element)
+		if c, ok := element.(Container); ok {
+			for _, child := range c.GetChildren() {
+				add(child)
+			}
+		}
+	}
+	for _, el := range elements {
+		if el.GetElementData().container == nil {
+			add(el)
+		}
+	}
+	return result
+}
+
+// Turns on or off the spatial navigation of the menu.
+// With spatial navigation the arrow keys focus the nearest focusable element in their direction,
+// TAB and Shift-TAB cycle through the focusable elements in the order they were added, the children of the containers follow their containers.
+// The next/prev keys of the elements aren't used
+func (m *NormalMenu) SetSpatialNavigation(enabled bool) {
+	m.spatialNav = enabled
+}
+
+// Moves the focus from the focused element (can be nil) by the navigation key.
+// Returns false if the key isn't a navigation key or there is nothing to focus
+func (m *NormalMenu) navigate(focused UIElement, key Key) bool {
+	var target UIElement
+	switch key {
+	case KeyUp, KeyDown, KeyLeft, KeyRight:
+		if focused == nil {
+			target = m.tabTarget(nil, 1)
+			break
+		}
+		target = m.nearest(focused, key)
+	case KeyTab:
+		target = m.tabTarget(focused, 1)
+	case KeyBackTab:
+		target = m.tabTarget(focused, -1)
+	}
+	if target == nil || target == focused {
+		return false
+	}
+	m.Focus(target)
+	return true
+}
+
+// Returns the focusable element that is step elements away from the element in the order of the focus traversal.
+// Wraps around the ends. If element is nil, returns the first focusable element
+func (m NormalMenu) tabTarget(element UIElement, step int) UIElement {
+	focusable := []UIElement{}
+	current := -1
+	for _, el := range elementOrder(m.elements) {
+		if el == element {
+			current = len(focusable)
+		}
+		if canFocus(el) || el == element {
+			focusable = append(focusable, el)
+		}
+	}
+	if len(focusable) == 0 {
+		return nil
+	}
+	if current == -1 {
+		return focusable[0]
+	}
+	i := (current + step + len(focusable)) % len(focusable)
+	return focusable[i]
+}
+
+// Returns the nearest focusable element in the direction of the arrow key, or nil
+func (m NormalMenu) nearest(element UIElement, key Key) UIElement {
+	var result UIElement
+	best := 0
+	for _, el := range m.elements {
+		if el == element || !canFocus(el) {
+			continue
+		}
+		distance, ok := spatialDistance(element, el, key)
+		if ok && (result == nil || distance < best) {
+			result = el
+			best = distance
+		}
+	}
+	return result
+}
+
+// Returns the gap between the ranges [aStart, aEnd) and [bStart, bEnd), 0 if they overlap
+func rangeGap(aStart, aEnd, bStart, bEnd int) int {
+	if bStart >= aEnd {
+		return bStart - aEnd + 1
+	}
+	if aStart >= bEnd {
+		return aStart - bEnd + 1
+	}
+	return 0
+}
+
+// Returns the distance from the element to the candidate in the direction of the arrow key.
+// Returns false if the candidate isn't in that direction.
+// Rows count twice as much as columns, because the cells are about twice as high as they are wide.
+// The distance across the direction counts twice as much as the distance along it.
+// Candidates that are more across than along the direction are further than all the others
+func spatialDistance(from, to UIElement, key Key) (int, bool) {
+	f := from.GetElementData()
+	t := to.GetElementData()
+	fBottom, fRight := f.yPos+from.Height(), f.xPos+from.Width()
+	tBottom, tRight := t.yPos+to.Height(), t.xPos+to.Width()
+	rows := rangeGap(f.yPos, fBottom, t.yPos, tBottom) * 2
+	cols := rangeGap(f.xPos, fRight, t.xPos, tRight)
+	along, across := rows, cols
+	switch key {
+	case KeyUp:
+		if tBottom > f.yPos {
+			return 0, false
+		}
+	case KeyDown:
+		if t.yPos < fBottom {
+			return 0, false
+		}
+	case KeyLeft:
+		if tRight > f.xPos {
+			return 0, false
+		}
+		along, across = cols, rows
+	case KeyRight:
+		if t.xPos < fRight {
+			return 0, false
+		}
+		along, across = cols, rows
+	default:
+		return 0, false
+	}
+	result := along + across*2
+	if across > along {
+		result += outOfConeDistance
+	}
+	return result, true
+}
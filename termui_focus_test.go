@@ -0,0 +1,155 @@
+package termui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpatialNavigation(t *testing.T) {
+	w, screen := newTestWindow(t, 13, 40)
+	menu := w.GetMenu().(*NormalMenu)
+	menu.SetSpatialNavigation(true)
+	buttons := map[string]*Button{}
+	addButton := func(name string, y, x int) {
+		button, err := NewButton(menu, y, x, name, func() error {
+			return nil
+		}, KeyEnter)
+		must(t, err)
+		buttons[name] = button
+	}
+	// the label, the progress bar, the pie chart and the separator lie between the buttons and are never focused
+	addButton("A", 0, 2)
+	addButton("B", 0, 20)
+	_, err := NewLabel(menu, 3, 2, "label")
+	must(t, err)
+	_, err = NewProgressBar(menu, 3, 20, 10, 10, false, "normal", "normal")
+	must(t, err)
+	addButton("C", 5, 2)
+	_, err = NewPieChart(menu, 4, 12, 3, 6, []int{1, 1}, []string{"red", "blue"}, "normal")
+	must(t, err)
+	addButton("F", 5, 30)
+	_, err = NewSeparator(menu, 8, "normal")
+	must(t, err)
+	addButton("D", 10, 2)
+	addButton("E", 10, 20)
+	for _, tt := range []struct {
+		from string
+		key  Key
+		want string
+	}{
+		{"A", KeyDown, "C"},
+		{"A", KeyRight, "B"},
+		{"B", KeyDown, "E"},
+		{"C", KeyRight, "F"},
+		{"F", KeyLeft, "C"},
+		{"D", KeyUp, "C"},
+		{"E", KeyLeft, "D"},
+		{"C", KeyUp, "A"},
+		// the arrows stop at the edges, tab and shift-tab wrap around
+		{"A", KeyUp, "A"},
+		{"D", KeyLeft, "D"},
+		{"E", KeyTab, "A"},
+		{"A", KeyBackTab, "E"},
+		{"B", KeyTab, "C"},
+	} {
+		menu.Focus(buttons[tt.from])
+		must(t, w.handleKey(tt.key))
+		got := ""
+		for name, button := range buttons {
+			if focusedElement(menu) == button {
+				got = name
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%v from %v focused %q, want %v", KeyName(tt.key), tt.from, got, tt.want)
+		}
+	}
+	menu.Focus(buttons["A"])
+	must(t, w.handleKey(KeyDown))
+	must(t, w.handleKey(KeyRight))
+	matchGolden(t, w, screen, "spatial")
+}
+
+func TestNavigationSkipsDisabled(t *testing.T) {
+	w, _ := newTestWindow(t, 5, 40)
+	menu := w.GetMenu().(*NormalMenu)
+	buttons := []UIElement{}
+	for i, text := range []string{"first", "second", "third"} {
+		button, err := NewButton(menu, 0, i*10, text, func() error {
+			return nil
+		}, KeyEnter)
+		must(t, err)
+		buttons = append(buttons, button)
+	}
+	Link(buttons...)
+	SetEnabled(buttons[1].(*Button), false)
+	for _, spatial := range []bool{false, true} {
+		menu.SetSpatialNavigation(spatial)
+		menu.Focus(buttons[0].(*Button))
+		key := KeyDown
+		if spatial {
+			key = KeyRight
+		}
+		must(t, w.handleKey(key))
+		if focusedElement(menu) != buttons[2] {
+			t.Errorf("spatial navigation %v: the focus didn't skip the disabled button", spatial)
+		}
+	}
+}
+
+func TestFocusHooks(t *testing.T) {
+	w, screen := newTestWindow(t, 5, 40)
+	menu := w.GetMenu().(*NormalMenu)
+	log := []string{}
+	buttons := []UIElement{}
+	for i, text := range []string{"a", "b", "c"} {
+		text := text
+		button, err := NewButton(menu, 0, i*10, text, func() error {
+			return nil
+		}, KeyEnter)
+		must(t, err)
+		OnFocus(button, func() {
+			log = append(log, "focus "+text)
+		})
+		OnBlur(button, func() {
+			log = append(log, "blur "+text)
+		})
+		buttons = append(buttons, button)
+	}
+	Link(buttons...)
+	expectLog := func(want ...string) {
+		t.Helper()
+		if strings.Join(log, ", ") != strings.Join(want, ", ") {
+			t.Fatalf("got the hooks %v, want %v", log, want)
+		}
+		log = nil
+	}
+	menu.Focus(buttons[0].(*Button))
+	expectLog("focus a")
+	// the focused element loses the focus before the next one gets it
+	must(t, w.handleKey(KeyDown))
+	expectLog("blur a", "focus b")
+	menu.Focus(buttons[1].(*Button))
+	expectLog()
+	screen.PushMouse(MouseEvent{Y: 1, X: 21, Button: MouseLeft, Action: MousePress})
+	must(t, w.handleKey(w.GetKey()))
+	expectLog("blur b", "focus c")
+	// the elements keep the focus of their menu while another menu is shown
+	other, err := NewNormalMenu("Other")
+	must(t, err)
+	w.SetMenu(other)
+	button, err := NewButton(other, 0, 0, "d", func() error {
+		return nil
+	}, KeyEnter)
+	must(t, err)
+	OnFocus(button, func() {
+		log = append(log, "focus d")
+	})
+	other.Focus(button)
+	expectLog("focus d")
+	w.SetMenu(menu)
+	expectLog()
+	if focusedElement(menu) != buttons[2] {
+		t.Fatal("the menu lost its focused element")
+	}
+}
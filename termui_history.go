@@ -0,0 +1,125 @@
+package termui
+
+import "fmt"
+
+// Pushes the menu above the current menu. The current menu is kept in the history and becomes current again when the pushed menu is popped
+func (w *Window) PushMenu(menu Menu) error {
+	err := w.leaveMenu(w.currentMenu)
+	if err != nil {
+		return err
+	}
+	return w.enterNewMenu(menu, append(w.history, w.currentMenu))
+}
+
+// Pops the current menu, the previous menu from the history becomes current again.
+// Returns an error if the history is empty
+func (w *Window) PopMenu() error {
+	if len(w.history) == 0 {
+		return fmt.Errorf("termui - no menu to go back to")
+	}
+	err := w.leaveMenu(w.currentMenu)
+	if err != nil {
+		return err
+	}
+	last := len(w.history) - 1
+	menu := w.history[last]
+	w.history = w.history[:last]
+	w.setCurrentMenu(menu)
+	return w.enterMenu(menu, true)
+}
+
+// Replaces the current menu with the menu. The history is kept
+func (w *Window) ReplaceMenu(menu Menu) error {
+	err := w.leaveMenu(w.currentMenu)
+	if err != nil {
+		return err
+	}
+	return w.enterNewMenu(menu, w.history)
+}
+
+// Replaces the current menu with the menu and drops the history, like SetMenu, but returns the first error of the hooks.
+// OnLeave is called for the current menu, then for the dropped menus from the last pushed to the first, even if some of them fail
+func (w *Window) ResetMenu(menu Menu) error {
+	leaveErr := w.leaveAll()
+	err := w.enterNewMenu(menu, nil)
+	if err != nil {
+		return err
+	}
+	return leaveErr
+}
+
+// Makes the menu current with the history and calls its OnEnter hook.
+// If the hook fails, the previous menu and history are restored and the previous menu is resumed
+func (w *Window) enterNewMenu(menu Menu, history []Menu) error {
+	previous, previousHistory := w.currentMenu, w.history
+	w.history = history
+	w.setCurrentMenu(menu)
+	err := w.enterMenu(menu, false)
+	if err != nil {
+		w.history = previousHistory
+		w.setCurrentMenu(previous)
+		w.enterMenu(previous, true)
+	}
+	return err
+}
+
+// Calls OnLeave for the current menu, then for the menus of the history from the last pushed to the first.
+// Returns the first error, the hooks of all the menus are called
+func (w *Window) leaveAll() error {
+	err := w.leaveMenu(w.currentMenu)
+	for i := len(w.history) - 1; i >= 0; i-- {
+		leaveErr := w.leaveMenu(w.history[i])
+		if err == nil {
+			err = leaveErr
+		}
+	}
+	return err
+}
+
+// Returns the menus below the current menu, from the first pushed to the last
+func (w *Window) GetHistory() []Menu {
+	return append([]Menu{}, w.history...)
+}
+
+// Returns true if there is a menu to go back to
+func (w *Window) CanGoBack() bool {
+	return len(w.history) > 0
+}
+
+// Sets the key that pops the current menu if there is a menu to go back to (disabled by default). KeyNone disables it.
+// The key goes back only if the focused element and the bindings of the menu don't handle it, and before the quit key of the menu
+func (w *Window) SetBackKey(key Key) {
+	w.backKey = key
+}
+
+// Pops the current menu if the key is the back key and there is a menu to go back to. Returns true if the menu was popped
+func (w *Window) goBack(key Key) (bool, error) {
+	if key == KeyNone || key != w.backKey || !w.CanGoBack() {
+		return false, nil
+	}
+	return true, w.PopMenu()
+}
+
+// Calls the OnLeave hook of the menu, if it has one. The hooks are called only while the window is running
+func (w *Window) leaveMenu(menu Menu) error {
+	leaver, ok := menu.(MenuLeaver)
+	if !ok || !w.running {
+		return nil
+	}
+	return leaver.OnLeave()
+}
+
+// Calls the OnEnter or, if the menu is resumed, the OnResume hook of the menu, if it has one.
+// The hooks are called only while the window is running
+func (w *Window) enterMenu(menu Menu, resumed bool) error {
+	if !w.running {
+		return nil
+	}
+	if resumer, ok := menu.(MenuResumer); ok && resumed {
+		return resumer.OnResume()
+	}
+	if enterer, ok := menu.(MenuEnterer); ok && !resumed {
+		return enterer.OnEnter()
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package termui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Menu that implements only the Menu interface, without the hooks
+type minimalMenu struct {
+	menu *NormalMenu
+}
+
+func (m minimalMenu) SetParent(window *Window) {
+	m.menu.SetParent(window)
+}
+
+func (m minimalMenu) Draw() error {
+	return m.menu.Draw()
+}
+
+func (m minimalMenu) HandleKey(key Key) (bool, error) {
+	return m.menu.HandleKey(key)
+}
+
+func (m minimalMenu) AddElement(element UIElement) {
+	m.menu.AddElement(element)
+}
+
+func (m minimalMenu) GetElements() []UIElement {
+	return m.menu.GetElements()
+}
+
+func (m minimalMenu) Focus(element hasElementData) {
+	m.menu.Focus(element)
+}
+
+func TestMenuHistory(t *testing.T) {
+	log := []string{}
+	// the menus record their hooks to the log
+	hooked := func(name string) *NormalMenu {
+		menu, err := NewNormalMenu(name)
+		must(t, err)
+		record := func(hook string) func() error {
+			return func() error {
+				log = append(log, name+"."+hook)
+				return nil
+			}
+		}
+		menu.SetOnEnter(record("enter"))
+		menu.SetOnLeave(record("leave"))
+		menu.SetOnResume(record("resume"))
+		return menu
+	}
+	expectLog := func(want string) {
+		t.Helper()
+		if got := strings.Join(log, " "); got != want {
+			t.Fatalf("got hooks %v, want %v", got, want)
+		}
+		log = nil
+	}
+
+	w, _ := newTestWindow(t, 5, 20)
+	first := hooked("first")
+	second := hooked("second")
+	third := hooked("third")
+	// the hooks aren't called before the window is started
+	w.SetMenu(first)
+	expectLog("")
+	w.running = true
+	must(t, w.PushMenu(second))
+	must(t, w.ReplaceMenu(third))
+	if !w.CanGoBack() || len(w.GetHistory()) != 1 {
+		t.Fatalf("got history %v", w.GetHistory())
+	}
+	must(t, w.PopMenu())
+	if w.GetMenu() != first || w.CanGoBack() {
+		t.Fatal("didn't go back to the first menu")
+	}
+	if w.PopMenu() == nil {
+		t.Fatal("popped the menu with an empty history")
+	}
+	expectLog("first.leave second.enter second.leave third.enter third.leave first.resume")
+
+	// SetMenu leaves the whole history
+	must(t, w.PushMenu(second))
+	must(t, w.PushMenu(third))
+	log = nil
+	w.SetMenu(hooked("fourth"))
+	expectLog("third.leave second.leave first.leave fourth.enter")
+	if w.CanGoBack() {
+		t.Fatal("the history wasn't cleared")
+	}
+
+	// the menus without the hooks are skipped
+	plain, err := NewNormalMenu("Plain")
+	must(t, err)
+	must(t, w.PushMenu(minimalMenu{plain}))
+	log = nil
+	must(t, w.PushMenu(hooked("hooked")))
+	must(t, w.PopMenu())
+	must(t, w.PopMenu())
+	expectLog("hooked.enter hooked.leave fourth.resume")
+
+	// the back key pops the second menu, then does nothing without the history
+	w, screen := newTestWindow(t, 5, 20)
+	w.SetMenu(hooked("first"))
+	w.SetBackKey(KeyBackspace)
+	second = hooked("second")
+	must(t, w.Bind("n", func() error {
+		return w.PushMenu(second)
+	}))
+	exits := 0
+	w.BeforeExit(func() bool {
+		exits++
+		return true
+	})
+	screen.PushKeys('n', KeyBackspace, KeyBackspace, KeyEscape)
+	must(t, w.Start())
+	expectLog("first.enter first.leave second.enter second.leave first.resume")
+	if exits != 1 {
+		t.Fatalf("requested the exit %d times, want 1", exits)
+	}
+}
+
+func TestMenuHookErrors(t *testing.T) {
+	log := []string{}
+	// the menus record their hooks to the log, the hooks in fails return errors
+	hooked := func(name string, fails ...string) *NormalMenu {
+		menu, err := NewNormalMenu(name)
+		must(t, err)
+		record := func(hook string) func() error {
+			return func() error {
+				log = append(log, name+"."+hook)
+				for _, failing := range fails {
+					if failing == hook {
+						return fmt.Errorf("%v can't %v", name, hook)
+					}
+				}
+				return nil
+			}
+		}
+		menu.SetOnEnter(record("enter"))
+		menu.SetOnLeave(record("leave"))
+		menu.SetOnResume(record("resume"))
+		return menu
+	}
+	expectLog := func(want string) {
+		t.Helper()
+		if got := strings.Join(log, " "); got != want {
+			t.Fatalf("got hooks %v, want %v", got, want)
+		}
+		log = nil
+	}
+
+	w, _ := newTestWindow(t, 5, 20)
+	w.running = true
+	first := hooked("first")
+	second := hooked("second")
+	w.SetMenu(first)
+	must(t, w.PushMenu(second))
+	log = nil
+	// the menu whose OnEnter fails isn't shown, the previous menu is resumed
+	if w.PushMenu(hooked("pushed", "enter")) == nil {
+		t.Fatal("expected the error of OnEnter")
+	}
+	expectLog("second.leave pushed.enter second.resume")
+	if w.ReplaceMenu(hooked("replacing", "enter")) == nil {
+		t.Fatal("expected the error of OnEnter")
+	}
+	expectLog("second.leave replacing.enter second.resume")
+	if w.ResetMenu(hooked("reset", "enter")) == nil {
+		t.Fatal("expected the error of OnEnter")
+	}
+	expectLog("second.leave first.leave reset.enter second.resume")
+	if history := w.GetHistory(); w.GetMenu() != second || len(history) != 1 || history[0] != first {
+		t.Fatalf("the menus weren't restored, history %v", history)
+	}
+
+	// all the menus are left even if some of them fail
+	must(t, w.PushMenu(hooked("third", "leave")))
+	log = nil
+	if w.ResetMenu(hooked("fourth")) == nil {
+		t.Fatal("expected the error of OnLeave")
+	}
+	expectLog("third.leave second.leave first.leave fourth.enter")
+	must(t, w.PushMenu(hooked("fifth", "leave")))
+	log = nil
+	// SetMenu shows the menu whatever the hooks return
+	last := hooked("last", "enter")
+	w.SetMenu(last)
+	expectLog("fifth.leave fourth.leave last.enter")
+	if w.GetMenu() != last || w.CanGoBack() {
+		t.Fatal("SetMenu didn't show the menu")
+	}
+}
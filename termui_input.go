@@ -0,0 +1,268 @@
+package termui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Returned by the dialogs when the input source of the window ran out of keys
+	ErrInputExhausted = errors.New("termui - input source is exhausted")
+
+	// Names of the keys, used in key scripts
+	keyNames = map[string]Key{
+		"enter":     KeyEnter,
+		"esc":       KeyEscape,
+		"escape":    KeyEscape,
+		"up":        KeyUp,
+		"down":      KeyDown,
+		"left":      KeyLeft,
+		"right":     KeyRight,
+		"backspace": KeyBackspace,
+		"tab":       KeyTab,
+		"backtab":   KeyBackTab,
+		"space":     ' ',
+		"home":      KeyHome,
+		"end":       KeyEnd,
+		"pgup":      KeyPageUp,
+		"pgdn":      KeyPageDown,
+		"insert":    KeyInsert,
+		"delete":    KeyDelete,
+		"f1":        KeyF1,
+		"f2":        KeyF2,
+		"f3":        KeyF3,
+		"f4":        KeyF4,
+		"f5":        KeyF5,
+		"f6":        KeyF6,
+		"f7":        KeyF7,
+		"f8":        KeyF8,
+		"f9":        KeyF9,
+		"f10":       KeyF10,
+		"f11":       KeyF11,
+		"f12":       KeyF12,
+		"resize":    KeyResize,
+	}
+)
+
+// A source of keys for the window
+type InputSource interface {
+	// Returns the next key. Returns false if the source has no more keys
+	NextKey() (Key, bool)
+}
+
+// Reads the keys from the screen
+type screenInput struct {
+	screen Screen
+}
+
+// Returns the key read from the screen
+func (s screenInput) NextKey() (Key, bool) {
+	return s.screen.GetKey(), true
+}
+
+// Returns the last mouse event of the screen, if the screen reports them
+func (s screenInput) LastMouse() MouseEvent {
+	if source, ok := s.screen.(MouseSource); ok {
+		return source.LastMouse()
+	}
+	return MouseEvent{}
+}
+
+// Input source that returns the keys of a script
+type ScriptInput struct {
+	keys  []Key
+	mice  map[int]MouseEvent
+	mouse MouseEvent
+	pos   int
+}
+
+// Creates an input source that returns the keys
+func NewKeysInput(keys ...Key) *ScriptInput {
+	result := ScriptInput{}
+	result.keys = keys
+	result.mice = map[int]MouseEvent{}
+	result.pos = 0
+	return &result
+}
+
+// Creates an input source from the key script
+//
+// Script example: Down Down "hello" Enter Click(3,5) Esc
+func NewScriptInput(script string) (*ScriptInput, error) {
+	keys, mice, err := parseKeyScript(script)
+	if err != nil {
+		return nil, err
+	}
+	result := NewKeysInput(keys...)
+	result.mice = mice
+	return result, nil
+}
+
+// Creates an input source from the key script file
+func NewFileInput(path string) (*ScriptInput, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewScriptInput(string(data))
+}
+
+// Returns the next key of the script
+func (s *ScriptInput) NextKey() (Key, bool) {
+	if s.pos >= len(s.keys) {
+		return 0, false
+	}
+	s.pos++
+	if event, has := s.mice[s.pos-1]; has {
+		s.mouse = event
+	}
+	return s.keys[s.pos-1], true
+}
+
+// Returns the mouse event of the last KeyMouse of the script
+func (s ScriptInput) LastMouse() MouseEvent {
+	return s.mouse
+}
+
+// Returns the amount of keys that weren't read yet
+func (s ScriptInput) Remaining() int {
+	return len(s.keys) - s.pos
+}
+
+// Input source that writes every key it reads to a key script
+type RecordingInput struct {
+	source InputSource
+	out    io.Writer
+}
+
+// Creates an input source that reads the keys from source and writes them to out.
+// The result can be replayed with NewScriptInput
+func NewRecordingInput(source InputSource, out io.Writer) *RecordingInput {
+	result := RecordingInput{}
+	result.source = source
+	result.out = out
+	return &result
+}
+
+// Returns the next key of the source, records it
+func (r *RecordingInput) NextKey() (Key, bool) {
+	key, ok := r.source.NextKey()
+	if !ok {
+		return key, ok
+	}
+	if key == KeyMouse {
+		fmt.Fprintln(r.out, mouseEventName(r.LastMouse()))
+		return key, ok
+	}
+	fmt.Fprintln(r.out, KeyName(key))
+	return key, ok
+}
+
+// Returns the last mouse event of the source, if the source reports them
+func (r RecordingInput) LastMouse() MouseEvent {
+	if source, ok := r.source.(MouseSource); ok {
+		return source.LastMouse()
+	}
+	return MouseEvent{}
+}
+
+// Returns the name of the key, as used in key scripts
+func KeyName(key Key) string {
+	if key&KeyAlt != 0 {
+		return "Alt+" + KeyName(key&^KeyAlt)
+	}
+	if key&KeyCtrl != 0 {
+		return "Ctrl+" + KeyName(key&^KeyCtrl)
+	}
+	for name, k := range keyNames {
+		// prefer the short name of escape
+		if k == key && name != "escape" {
+			return strings.ToUpper(name[:1]) + name[1:]
+		}
+	}
+	for base, shifted := range shiftKeys {
+		if shifted == key {
+			return "Shift+" + KeyName(base)
+		}
+	}
+	if key >= KeyShiftF1 && key <= KeyShiftF1+KeyF12-KeyF1 {
+		return "Shift+" + KeyName(key-KeyShiftF1+KeyF1)
+	}
+	if key == 0 {
+		return "Ctrl+Space"
+	}
+	if key >= 1 && key <= 26 {
+		return "Ctrl+" + string(rune(key-1+'a'))
+	}
+	// the quote starts text and # starts a comment in key scripts, so they are written as codes
+	if key > ' ' && key < 127 && key != '"' && key != '#' {
+		return string(rune(key))
+	}
+	return "<" + strconv.Itoa(int(key)) + ">"
+}
+
+// Parses the key script.
+// The script consists of key names (Enter, Esc, Up, Down, Left, Right, Backspace, Tab, Space, Home, End, PgUp, PgDn, F1 etc.),
+// single characters, key codes (<410>), keys with modifiers (Ctrl+s, Alt+x, Shift+Up), quoted text ("hello") and mouse events
+// (Click(y,x), Press(y,x), Release(y,x), Drag(y,x), RightPress(y,x), WheelUp(y,x), WheelDown(y,x)), separated by white space.
+// Mouse events are returned as KeyMouse.
+// Lines that start with # are ignored
+func ParseKeyScript(script string) ([]Key, error) {
+	result, _, err := parseKeyScript(script)
+	return result, err
+}
+
+// Parses the key script. Returns the keys and the mouse events of the KeyMouse keys by their index
+func parseKeyScript(script string) ([]Key, map[int]MouseEvent, error) {
+	result := []Key{}
+	mice := map[int]MouseEvent{}
+	scanner := bufio.NewScanner(strings.NewReader(script))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		for len(line) > 0 {
+			var token string
+			if line[0] == '"' {
+				end := strings.IndexRune(line[1:], '"')
+				if end == -1 {
+					return nil, nil, fmt.Errorf("termui - unterminated text in key script line %v", line)
+				}
+				for _, ch := range line[1 : end+1] {
+					result = append(result, Key(ch))
+				}
+				line = strings.TrimSpace(line[end+2:])
+				continue
+			}
+			split := strings.IndexAny(line, " \t")
+			if split == -1 {
+				token, line = line, ""
+			} else {
+				token, line = line[:split], strings.TrimSpace(line[split:])
+			}
+			events, isMouse, err := parseMouseToken(token)
+			if err != nil {
+				return nil, nil, err
+			}
+			if isMouse {
+				for _, event := range events {
+					mice[len(result)] = event
+					result = append(result, KeyMouse)
+				}
+				continue
+			}
+			key, err := ParseKey(token)
+			if err != nil {
+				return nil, nil, err
+			}
+			result = append(result, key)
+		}
+	}
+	return result, mice, scanner.Err()
+}
@@ -0,0 +1,121 @@
+package termui
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestParseKeyScript(t *testing.T) {
+	tests := []struct {
+		script string
+		keys   []Key
+	}{
+		{"Down Down Enter", []Key{KeyDown, KeyDown, KeyEnter}},
+		{`"hi" Esc`, []Key{'h', 'i', KeyEscape}},
+		{"a <410> Space", []Key{'a', KeyResize, ' '}},
+		{"Ctrl+s Alt+x Shift+Up", []Key{19, KeyAlt | 'x', KeyShiftUp}},
+		{"Ctrl+PgDn", []Key{KeyCtrl | KeyPageDown}},
+		{"# a comment\nTab\n  # another\nBackTab", []Key{KeyTab, KeyBackTab}},
+		{"Click(3,5)", []Key{KeyMouse, KeyMouse}},
+		{"", []Key{}},
+	}
+	for _, test := range tests {
+		keys, err := ParseKeyScript(test.script)
+		if err != nil {
+			t.Errorf("%q: %v", test.script, err)
+			continue
+		}
+		if !reflect.DeepEqual(keys, test.keys) {
+			t.Errorf("%q: expected %v, got %v", test.script, test.keys, keys)
+		}
+	}
+}
+
+func TestParseKeyScriptErrors(t *testing.T) {
+	scripts := []string{
+		"NoSuchKey",
+		"<abc>",
+		"<>",
+		`"unterminated`,
+		"Hyper+a",
+		"Click(3)",
+		"Click(a,5)",
+		"Press(3,b)",
+	}
+	for _, script := range scripts {
+		_, err := ParseKeyScript(script)
+		if err == nil {
+			t.Errorf("%q: expected an error", script)
+		}
+	}
+}
+
+func TestScriptInputMouse(t *testing.T) {
+	input, err := NewScriptInput("a WheelUp(2,4) b")
+	must(t, err)
+	for i, key := range []Key{'a', KeyMouse, 'b'} {
+		got, ok := input.NextKey()
+		if !ok || got != key {
+			t.Fatalf("key %v: expected %v, got %v (%v)", i, key, got, ok)
+		}
+		if key == KeyMouse {
+			event := input.LastMouse()
+			if event.Button != MouseWheelUp || event.Y != 2 || event.X != 4 {
+				t.Fatalf("unexpected mouse event %+v", event)
+			}
+		}
+	}
+	if _, ok := input.NextKey(); ok {
+		t.Fatal("the script should be exhausted")
+	}
+	if input.Remaining() != 0 {
+		t.Fatalf("expected no remaining keys, got %v", input.Remaining())
+	}
+}
+
+func TestRecordingInputReplay(t *testing.T) {
+	script := `Up Down "a#b c" Enter Ctrl+x Alt+q Shift+F5 Ctrl+Left F12 Tab BackTab Backspace <500> Click(1,2) RightPress(0,7) Esc Space "\"`
+	source, err := NewScriptInput(script)
+	must(t, err)
+	out := bytes.Buffer{}
+	recording := NewRecordingInput(source, &out)
+	recorded := []Key{}
+	recordedMice := []MouseEvent{}
+	for {
+		key, ok := recording.NextKey()
+		if !ok {
+			break
+		}
+		recorded = append(recorded, key)
+		if key == KeyMouse {
+			recordedMice = append(recordedMice, recording.LastMouse())
+		}
+	}
+	replay, err := NewScriptInput(out.String())
+	must(t, err)
+	for i, key := range recorded {
+		got, ok := replay.NextKey()
+		if !ok || got != key {
+			t.Fatalf("key %v: recorded %v, replayed %v\nscript:\n%s", i, KeyName(key), KeyName(got), out.String())
+		}
+		if key == KeyMouse {
+			if replay.LastMouse() != recordedMice[0] {
+				t.Fatalf("recorded %+v, replayed %+v", recordedMice[0], replay.LastMouse())
+			}
+			recordedMice = recordedMice[1:]
+		}
+	}
+	if replay.Remaining() != 0 {
+		t.Fatalf("the replay has %v extra keys", replay.Remaining())
+	}
+}
+
+func TestWindowInputExhausted(t *testing.T) {
+	w, screen := newTestWindow(t, 5, 20)
+	w.SetInput(NewKeysInput('a', 'b'))
+	must(t, w.Start())
+	if !screen.Ended() {
+		t.Fatal("the window didn't stop when the input was exhausted")
+	}
+}
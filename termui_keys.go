@@ -0,0 +1,309 @@
+package termui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Keys of shift + the named keys
+	shiftKeys = map[Key]Key{
+		KeyTab:   KeyBackTab,
+		KeyUp:    KeyShiftUp,
+		KeyDown:  KeyShiftDown,
+		KeyLeft:  KeyShiftLeft,
+		KeyRight: KeyShiftRight,
+		KeyHome:  KeyShiftHome,
+		KeyEnd:   KeyShiftEnd,
+	}
+	// Keys of ctrl + the punctuation characters
+	ctrlKeys = map[rune]Key{
+		'@':  0,
+		' ':  0,
+		'[':  27,
+		'\\': 28,
+		']':  29,
+		'^':  30,
+		'_':  31,
+	}
+	// Special keys the screens report together with ctrl
+	ctrlSpecialKeys = map[Key]bool{
+		KeyUp:       true,
+		KeyDown:     true,
+		KeyLeft:     true,
+		KeyRight:    true,
+		KeyHome:     true,
+		KeyEnd:      true,
+		KeyPageUp:   true,
+		KeyPageDown: true,
+	}
+)
+
+// Parses the key description, like "ctrl+s", "alt+x", "shift+tab", "f5", "enter" or "q".
+// Modifiers (ctrl, alt, shift) are separated from the key with +
+func ParseKey(description string) (Key, error) {
+	parts := strings.Split(description, "+")
+	base := parts[len(parts)-1]
+	modifiers := parts[:len(parts)-1]
+	if base == "" && len(parts) > 1 {
+		// the key is + itself, like "ctrl++"
+		base = "+"
+		modifiers = parts[:len(parts)-2]
+	}
+	result, err := parseBaseKey(base)
+	if err != nil {
+		return 0, err
+	}
+	alt := false
+	for _, modifier := range modifiers {
+		switch strings.ToLower(modifier) {
+		case "ctrl", "control":
+			result, err = ctrlKey(result)
+		case "shift":
+			result, err = shiftKey(result)
+		case "alt", "meta":
+			alt = true
+		default:
+			err = fmt.Errorf("termui - unknown modifier %v in key %v", modifier, description)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if alt {
+		result |= KeyAlt
+	}
+	return result, nil
+}
+
+// Parses the key sequence, like "ctrl+x ctrl+c". The keys are separated by white space
+func ParseKeys(description string) ([]Key, error) {
+	fields := strings.Fields(description)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("termui - key sequence %q is empty", description)
+	}
+	result := make([]Key, 0, len(fields))
+	for _, field := range fields {
+		key, err := ParseKey(field)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, key)
+	}
+	return result, nil
+}
+
+// Parses the key without the modifiers
+func parseBaseKey(base string) (Key, error) {
+	if key, has := keyNames[strings.ToLower(base)]; has {
+		return key, nil
+	}
+	if strings.HasPrefix(base, "<") && strings.HasSuffix(base, ">") {
+		code, err := strconv.Atoi(base[1 : len(base)-1])
+		if err != nil {
+			return 0, fmt.Errorf("termui - %v is not a valid key code", base)
+		}
+		return Key(code), nil
+	}
+	runes := []rune(base)
+	if len(runes) == 1 {
+		return Key(runes[0]), nil
+	}
+	return 0, fmt.Errorf("termui - can't recognize key %v", base)
+}
+
+// Returns the key of ctrl + key
+func ctrlKey(key Key) (Key, error) {
+	switch {
+	case key >= 'a' && key <= 'z':
+		return key - 'a' + 1, nil
+	case key >= 'A' && key <= 'Z':
+		return key - 'A' + 1, nil
+	}
+	if result, has := ctrlKeys[rune(key)]; has {
+		return result, nil
+	}
+	if ctrlSpecialKeys[key] {
+		return key | KeyCtrl, nil
+	}
+	return 0, fmt.Errorf("termui - %v can't be pressed with ctrl", KeyName(key))
+}
+
+// Returns the key of shift + key
+func shiftKey(key Key) (Key, error) {
+	switch {
+	case key >= 'a' && key <= 'z':
+		return key - 'a' + 'A', nil
+	case key >= KeyF1 && key <= KeyF12:
+		return key - KeyF1 + KeyShiftF1, nil
+	}
+	if result, has := shiftKeys[key]; has {
+		return result, nil
+	}
+	return 0, fmt.Errorf("termui - %v can't be pressed with shift", KeyName(key))
+}
+
+// A key sequence and the function it calls
+type keyBinding struct {
+	keys []Key
+	fn   func() error
+}
+
+// Key bindings of a window, menu or element. A binding is a key or a sequence of keys (a chord)
+type KeyBindings struct {
+	bindings []keyBinding
+}
+
+// Creates empty key bindings
+func NewKeyBindings() *KeyBindings {
+	result := KeyBindings{}
+	result.bindings = []keyBinding{}
+	return &result
+}
+
+// Binds the key sequence, like "ctrl+s" or "ctrl+x ctrl+c", to fn. Replaces the previous binding of the sequence
+func (b *KeyBindings) Bind(keys string, fn func() error) error {
+	seq, err := ParseKeys(keys)
+	if err != nil {
+		return err
+	}
+	b.Unbind(keys)
+	b.bindings = append(b.bindings, keyBinding{keys: seq, fn: fn})
+	return nil
+}
+
+// Removes the binding of the key sequence
+func (b *KeyBindings) Unbind(keys string) error {
+	seq, err := ParseKeys(keys)
+	if err != nil {
+		return err
+	}
+	for i, binding := range b.bindings {
+		if keysEqual(binding.keys, seq) {
+			b.bindings = append(b.bindings[:i], b.bindings[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Returns the function bound to the sequence.
+// If the sequence is the beginning of a longer binding, returns true instead
+func (b *KeyBindings) match(seq []Key) (func() error, bool) {
+	if b == nil {
+		return nil, false
+	}
+	isPrefix := false
+	for _, binding := range b.bindings {
+		if keysEqual(binding.keys, seq) {
+			return binding.fn, false
+		}
+		if len(binding.keys) > len(seq) && keysEqual(binding.keys[:len(seq)], seq) {
+			isPrefix = true
+		}
+	}
+	return nil, isPrefix
+}
+
+// Returns true if the key sequences are the same
+func keysEqual(a, b []Key) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Binds the key sequence of the element to fn. The binding works while the element is focused
+func Bind(element hasElementData, keys string, fn func() error) error {
+	data := element.GetElementData()
+	if data.bindings == nil {
+		data.bindings = NewKeyBindings()
+	}
+	return data.bindings.Bind(keys, fn)
+}
+
+// Returns the focused element of the menu, or nil
+func focusedElement(menu Menu) UIElement {
+	for _, el := range menu.GetElements() {
+		if el.GetElementData().focused {
+			return el
+		}
+	}
+	return nil
+}
+
+// Sends the key to the current menu. Keys the menu doesn't handle go to the bindings of the window,
+// then ctrl+C and ctrl+Z are handled like the signals.
+// While a chord is typed, the keys go to the bindings of the focused element, its containers, the menu and the window, in that order
+func (w *Window) handleKey(key Key) error {
+	if key == KeyMouse {
+		_, err := w.currentMenu.HandleKey(key)
+		return err
+	}
+	if len(w.chord) > 0 {
+		seq := append(w.chord, key)
+		w.chord = nil
+		levels := []*KeyBindings{}
+		if el := focusedElement(w.currentMenu); el != nil {
+			levels = append(levels, el.GetElementData().bindings)
+			for c := el.GetElementData().container; c != nil; c = c.GetElementData().container {
+				levels = append(levels, c.GetElementData().bindings)
+			}
+		}
+		if menu, ok := w.currentMenu.(Bindable); ok {
+			levels = append(levels, menu.GetBindings())
+		}
+		levels = append(levels, w.bindings)
+		for _, bindings := range levels {
+			fn, isPrefix := bindings.match(seq)
+			if fn != nil {
+				return fn()
+			}
+			if isPrefix {
+				// wait for the rest of the chord
+				w.chord = seq
+				return nil
+			}
+		}
+		// the chord is broken, the key is handled on its own
+	}
+	handled, err := w.currentMenu.HandleKey(key)
+	if handled || err != nil {
+		return err
+	}
+	handled, err = w.goBack(key)
+	if handled || err != nil {
+		return err
+	}
+	handled, err = w.callBinding(w.bindings, key)
+	if handled || err != nil {
+		return err
+	}
+	w.handleSignalKey(key)
+	return nil
+}
+
+// Calls the binding of the key. If the key starts a chord, waits for the rest of it.
+// Returns false if the bindings don't have the key
+func (w *Window) callBinding(bindings *KeyBindings, key Key) (bool, error) {
+	fn, isPrefix := bindings.match([]Key{key})
+	if fn != nil {
+		return true, fn()
+	}
+	if isPrefix {
+		w.chord = []Key{key}
+		return true, nil
+	}
+	return false, nil
+}
+
+// Binds the key sequence, like "ctrl+s" or "ctrl+x ctrl+c", to fn. The binding works in every menu of the window
+func (w *Window) Bind(keys string, fn func() error) error {
+	return w.bindings.Bind(keys, fn)
+}
@@ -0,0 +1,194 @@
+package termui
+
+import "testing"
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		description string
+		want        Key
+	}{
+		{"q", 'q'},
+		{"Q", 'Q'},
+		{"+", '+'},
+		{"enter", KeyEnter},
+		{"ctrl+s", 19},
+		{"Ctrl+S", 19},
+		{"ctrl+[", KeyEscape},
+		{"ctrl+space", 0},
+		{"alt+x", KeyAlt | 'x'},
+		{"meta+x", KeyAlt | 'x'},
+		{"alt++", KeyAlt | '+'},
+		{"shift+a", 'A'},
+		{"shift+tab", KeyBackTab},
+		{"shift+f1", KeyShiftF1},
+		{"shift+up", KeyShiftUp},
+		{"f5", KeyF5},
+		{"<410>", KeyResize},
+		{"ctrl+up", KeyCtrl | KeyUp},
+		{"ctrl+home", KeyCtrl | KeyHome},
+		{"ctrl+pgdn", KeyCtrl | KeyPageDown},
+		{"alt+ctrl+left", KeyAlt | KeyCtrl | KeyLeft},
+	}
+	for _, tt := range tests {
+		got, err := ParseKey(tt.description)
+		if err != nil {
+			t.Errorf("%q: %v", tt.description, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.description, KeyName(got), KeyName(tt.want))
+		}
+	}
+}
+
+func TestParseKeyErrors(t *testing.T) {
+	for _, description := range []string{
+		"",
+		"foo",
+		"<abc>",
+		"hyper+x",
+		"ctrl+1",
+		"ctrl+f5",
+		"ctrl+delete",
+		"ctrl+insert",
+		"ctrl+tab",
+		"shift+1",
+		"shift+delete",
+	} {
+		if key, err := ParseKey(description); err == nil {
+			t.Errorf("%q: expected an error, got %s", description, KeyName(key))
+		}
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	got, err := ParseKeys(" ctrl+x  ctrl+c ")
+	must(t, err)
+	if !keysEqual(got, []Key{24, 3}) {
+		t.Fatalf("got %v", got)
+	}
+	for _, description := range []string{"", "   ", "ctrl+x foo"} {
+		if _, err := ParseKeys(description); err == nil {
+			t.Errorf("%q: expected an error", description)
+		}
+	}
+}
+
+func TestKeyBindingsBindUnbind(t *testing.T) {
+	b := NewKeyBindings()
+	calls := []string{}
+	must(t, b.Bind("ctrl+s", func() error {
+		calls = append(calls, "first")
+		return nil
+	}))
+	// the second binding of the sequence replaces the first one
+	must(t, b.Bind("ctrl+s", func() error {
+		calls = append(calls, "second")
+		return nil
+	}))
+	must(t, b.Bind("ctrl+x ctrl+s", func() error {
+		return nil
+	}))
+	fn, isPrefix := b.match([]Key{19})
+	if fn == nil || isPrefix {
+		t.Fatal("ctrl+s isn't bound")
+	}
+	must(t, fn())
+	if len(calls) != 1 || calls[0] != "second" {
+		t.Fatalf("got calls %v", calls)
+	}
+	if fn, isPrefix := b.match([]Key{24}); fn != nil || !isPrefix {
+		t.Fatal("ctrl+x isn't the beginning of a chord")
+	}
+	must(t, b.Unbind("ctrl+s"))
+	if fn, _ := b.match([]Key{19}); fn != nil {
+		t.Fatal("ctrl+s is still bound")
+	}
+	if b.Bind("ctrl+f5", nil) == nil {
+		t.Fatal("expected an error for ctrl+f5")
+	}
+}
+
+func TestWindowChord(t *testing.T) {
+	w, _ := newTestWindow(t, 5, 20)
+	saved := 0
+	must(t, w.Bind("ctrl+x ctrl+s", func() error {
+		saved++
+		return nil
+	}))
+	must(t, w.handleKey(24))
+	must(t, w.handleKey(19))
+	if saved != 1 {
+		t.Fatalf("the chord was called %d times, want 1", saved)
+	}
+	// a broken chord drops the keys typed so far
+	must(t, w.handleKey(24))
+	must(t, w.handleKey('q'))
+	must(t, w.handleKey(19))
+	if saved != 1 {
+		t.Fatal("the broken chord was called")
+	}
+}
+
+func TestBindingsOrder(t *testing.T) {
+	w, _ := newTestWindow(t, 5, 20)
+	menu := w.GetMenu().(*NormalMenu)
+	button, err := NewButton(menu, 0, 0, "Button", func() error {
+		return nil
+	}, KeyEnter)
+	must(t, err)
+	menu.Focus(button)
+	called := ""
+	bind := func(name string) func() error {
+		return func() error {
+			called = name
+			return nil
+		}
+	}
+	must(t, w.Bind("ctrl+s", bind("window")))
+	must(t, w.Bind("ctrl+w", bind("window")))
+	must(t, menu.Bind("ctrl+s", bind("menu")))
+	must(t, menu.Bind("ctrl+e", bind("menu")))
+	must(t, Bind(button, "ctrl+e", bind("element")))
+	for _, tt := range []struct {
+		key  Key
+		want string
+	}{
+		{19, "menu"},
+		{5, "element"},
+		{23, "window"},
+	} {
+		called = ""
+		must(t, w.handleKey(tt.key))
+		if called != tt.want {
+			t.Errorf("%s: called the binding of the %v, want the %v", KeyName(tt.key), called, tt.want)
+		}
+	}
+}
+
+// Menu that implements only the Menu interface
+type plainMenu struct {
+	*NormalMenu
+}
+
+func (m plainMenu) GetBindings() {}
+
+func TestMenuWithoutBindings(t *testing.T) {
+	w, _ := newTestWindow(t, 5, 20)
+	if _, ok := interface{}(plainMenu{}).(Bindable); ok {
+		t.Fatal("plainMenu shouldn't be Bindable")
+	}
+	called := false
+	must(t, w.Bind("ctrl+x ctrl+s", func() error {
+		called = true
+		return nil
+	}))
+	menu, err := NewNormalMenu("Plain")
+	must(t, err)
+	w.SetMenu(plainMenu{menu})
+	must(t, w.handleKey(24))
+	must(t, w.handleKey(19))
+	if !called {
+		t.Fatal("the chord of the window wasn't called")
+	}
+}
@@ -0,0 +1,105 @@
+package termui
+
+// A layer drawn above the menu of the window, like a popup or a dialog.
+// Layers that implement Resizable are notified about the resizes of the window
+type Layer interface {
+	// Draws the layer over the menu and the layers below it
+	Draw(s Surface) error
+	// Returns true if the key was handled. Keys that a non-modal layer doesn't handle go to the layers below it
+	HandleKey(key Key) (bool, error)
+}
+
+// A layer of the window and its modality
+type windowLayer struct {
+	layer Layer
+	modal bool
+}
+
+// Puts the layer on top of the layers of the window. A modal layer blocks the keys to the layers below it and to the menu
+func (w *Window) PushLayer(layer Layer, modal bool) {
+	w.layers = append(w.layers, windowLayer{layer: layer, modal: modal})
+	if r, ok := layer.(Resizable); ok {
+		r.OnResize(w.height, w.width)
+	}
+}
+
+// Removes the layer from the window. The layers above it stay
+func (w *Window) RemoveLayer(layer Layer) {
+	for i, l := range w.layers {
+		if l.layer == layer {
+			w.layers = append(w.layers[:i], w.layers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Returns true if the layer is in the window
+func (w *Window) HasLayer(layer Layer) bool {
+	for _, l := range w.layers {
+		if l.layer == layer {
+			return true
+		}
+	}
+	return false
+}
+
+// Puts the modal layer on top of the layers and runs the window until the layer is removed.
+// The menu and the layers below keep being drawn and updated.
+// If the window exits or the input is exhausted, removes the layer and returns the error
+func (w *Window) RunLayer(layer Layer) error {
+	w.PushLayer(layer, true)
+	for w.HasLayer(layer) {
+		err := w.draw()
+		if err != nil {
+			w.RemoveLayer(layer)
+			return err
+		}
+		key, isKey := w.waitEvent(true)
+		if !isKey {
+			// updates were applied, redraw
+			continue
+		}
+		if err = w.closedErr(); err != nil {
+			w.RemoveLayer(layer)
+			return err
+		}
+		if key == KeyResize {
+			w.resize()
+			continue
+		}
+		err = w.dispatchKey(key)
+		if err != nil {
+			w.RemoveLayer(layer)
+			return err
+		}
+	}
+	return nil
+}
+
+// Draws the menu, then the layers from the bottom to the top
+func (w *Window) draw() error {
+	err := w.currentMenu.Draw()
+	if err != nil {
+		return err
+	}
+	for _, l := range w.layers {
+		err = l.layer.Draw(w.screen)
+		if err != nil {
+			return err
+		}
+	}
+	w.screen.Refresh()
+	return nil
+}
+
+// Sends the key to the layers from the top. Keys that no layer handles go to the menu, unless a modal layer blocks them
+func (w *Window) dispatchKey(key Key) error {
+	for i := len(w.layers) - 1; i >= 0; i-- {
+		l := w.layers[i]
+		handled, err := l.layer.HandleKey(key)
+		if handled || err != nil || l.modal {
+			return err
+		}
+	}
+	return w.handleKey(key)
+}
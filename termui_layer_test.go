@@ -0,0 +1,95 @@
+package termui
+
+import "testing"
+
+// Layer that draws its name and records the keys it gets
+type testLayer struct {
+	name    string
+	y       int
+	handles Key
+	keys    []Key
+}
+
+func (l *testLayer) Draw(s Surface) error {
+	Put(s, l.y, 2, l.name, AttrReverse)
+	return nil
+}
+
+func (l *testLayer) HandleKey(key Key) (bool, error) {
+	l.keys = append(l.keys, key)
+	return key == l.handles, nil
+}
+
+func TestLayersGolden(t *testing.T) {
+	w, screen := newTestWindow(t, 5, 20)
+	_, err := NewLabel(w.GetMenu(), 1, 0, "menu label here")
+	must(t, err)
+	w.PushLayer(&testLayer{name: "bottom layer", y: 2}, false)
+	w.PushLayer(&testLayer{name: "top", y: 2}, false)
+	matchGolden(t, w, screen, "layers")
+}
+
+func TestLayerKeys(t *testing.T) {
+	w, _ := newTestWindow(t, 5, 20)
+	menu := w.GetMenu()
+	recorder := newKeyRecorder(menu, 'm')
+	menu.Focus(recorder)
+	bottom := &testLayer{handles: 'b'}
+	top := &testLayer{handles: 't'}
+	w.PushLayer(bottom, false)
+	w.PushLayer(top, false)
+	for _, key := range []Key{'t', 'b', 'm'} {
+		must(t, w.dispatchKey(key))
+	}
+	if !keysEqual(top.keys, []Key{'t', 'b', 'm'}) || !keysEqual(bottom.keys, []Key{'b', 'm'}) || !keysEqual(recorder.keys, []Key{'m'}) {
+		t.Fatalf("top got %v, bottom got %v, the menu got %v", top.keys, bottom.keys, recorder.keys)
+	}
+	// the modal layer blocks the keys it doesn't handle
+	modal := &testLayer{handles: 'x'}
+	w.PushLayer(modal, true)
+	must(t, w.dispatchKey('m'))
+	if len(top.keys) != 3 || len(recorder.keys) != 1 {
+		t.Fatal("the key went below the modal layer")
+	}
+	w.RemoveLayer(modal)
+	if w.HasLayer(modal) || !w.HasLayer(top) {
+		t.Fatal("removed the wrong layer")
+	}
+	must(t, w.dispatchKey('m'))
+	if len(recorder.keys) != 2 {
+		t.Fatal("the key didn't reach the menu after the modal layer was removed")
+	}
+}
+
+func TestRunLayerDialogs(t *testing.T) {
+	w, screen := newTestWindow(t, 12, 40)
+	screen.PushKeys('a', 'b', KeyBackspace, 'c', KeyEnter)
+	text, err := EnterString(w, "", "Name:", 10, "normal")
+	must(t, err)
+	if text != "ac" {
+		t.Fatalf("entered %q, want \"ac\"", text)
+	}
+	screen.PushKeys(KeyDown, KeyEnter)
+	picked, err := DropDownBox(w, []string{"first", "second", "third"}, 3, 1, 1, SingleElement, "normal")
+	must(t, err)
+	if len(picked) != 1 || picked[0] != 1 {
+		t.Fatalf("picked %v, want [1]", picked)
+	}
+	if len(w.layers) != 0 {
+		t.Fatalf("%v layers are left", len(w.layers))
+	}
+}
+
+func TestRunLayerExit(t *testing.T) {
+	w, _ := newTestWindow(t, 12, 40)
+	layer := &testLayer{handles: 'q'}
+	w.QueueUpdate(func() {
+		w.Exit()
+	})
+	if err := w.RunLayer(layer); err == nil {
+		t.Fatal("expected an error after the exit")
+	}
+	if w.HasLayer(layer) {
+		t.Fatal("the layer is left after the exit")
+	}
+}
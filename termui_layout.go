@@ -0,0 +1,389 @@
+package termui
+
+import "fmt"
+
+// An element that places its children. The children are elements of the same menu
+type Container interface {
+	UIElement
+
+	// Places the children relative to the location of the container
+	Layout()
+	// Returns the elements placed by the container
+	GetChildren() []UIElement
+}
+
+// A container that shows only some of its children
+type childShower interface {
+	// Returns true if the child is shown
+	isChildShown(data *UIElementData) bool
+}
+
+// Returns true if the element and all of its containers are visible
+func isShown(element hasElementData) bool {
+	data := element.GetElementData()
+	for data != nil {
+		if !data.Visible {
+			return false
+		}
+		if data.container == nil {
+			break
+		}
+		if shower, ok := data.container.(childShower); ok && !shower.isChildShown(data) {
+			return false
+		}
+		data = data.container.GetElementData()
+	}
+	return true
+}
+
+// Returns true if the element is inside the container or inside its children
+func inContainer(element hasElementData, container UIElement) bool {
+	for c := element.GetElementData().container; c != nil; c = c.GetElementData().container {
+		if c == container {
+			return true
+		}
+	}
+	return false
+}
+
+// Places the children of the containers that aren't inside other containers
+func layoutElements(elements []UIElement) {
+	for _, el := range elements {
+		if c, ok := el.(Container); ok && el.GetElementData().container == nil {
+			c.Layout()
+		}
+	}
+}
+
+// Moves the element to the absolute location. Containers place their children again
+func moveElement(element UIElement, y, x int) {
+	data := element.GetElementData()
+	data.yPos = y
+	data.xPos = x
+	if c, ok := element.(Container); ok {
+		c.Layout()
+	}
+}
+
+// Makes the container the parent of the element
+func adopt(container, element UIElement) error {
+	data := element.GetElementData()
+	if data.container != nil {
+		return fmt.Errorf("termui - element is already in a container")
+	}
+	for c := container; c != nil; c = c.GetElementData().container {
+		if c == element {
+			return fmt.Errorf("termui - can't add a container to itself")
+		}
+	}
+	data.container = container
+	return nil
+}
+
+// Returns the children that take space
+func shownChildren(children []UIElement) []UIElement {
+	result := []UIElement{}
+	for _, child := range children {
+		if child.GetElementData().Visible {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// A stack of elements. The base of VBox and HBox
+type box struct {
+	data     *UIElementData
+	children []UIElement
+	vertical bool
+	// The space between the border of the box and the children
+	Padding int
+	// The space between the children
+	Spacing int
+}
+
+// Creates the box
+func createBox(y, x, spacing int, vertical bool) *box {
+	result := box{}
+	result.data = createUIED(y, x)
+	result.data.Focusable = false
+	result.vertical = vertical
+	result.Spacing = spacing
+	result.children = []UIElement{}
+	return &result
+}
+
+// Adds the elements to the end of the box. The container is the element the box is embedded in
+func (b *box) add(container UIElement, elements []UIElement) error {
+	for _, element := range elements {
+		err := adopt(container, element)
+		if err != nil {
+			return err
+		}
+		b.children = append(b.children, element)
+	}
+	b.Layout()
+	return nil
+}
+
+// Returns the children of the box
+func (b box) GetChildren() []UIElement {
+	return b.children
+}
+
+// Places the children one after another. Hidden children don't take space
+func (b box) Layout() {
+	y := b.data.yPos + b.Padding
+	x := b.data.xPos + b.Padding
+	for _, child := range shownChildren(b.children) {
+		moveElement(child, y, x)
+		if b.vertical {
+			y += child.Height() + b.Spacing
+		} else {
+			x += child.Width() + b.Spacing
+		}
+	}
+}
+
+// Returns the element data of the box
+func (b box) GetElementData() *UIElementData {
+	return b.data
+}
+
+// The box doesn't draw anything, the children are drawn by the menu
+func (b box) Draw(s Surface) error {
+	return nil
+}
+
+// Doesn't handle any keys
+func (b box) HandleKey(key Key) (bool, error) {
+	return false, nil
+}
+
+// Returns the height of the box
+func (b box) Height() int {
+	children := shownChildren(b.children)
+	result := 0
+	for _, child := range children {
+		if b.vertical {
+			result += child.Height()
+		} else {
+			result = MaxInt(result, child.Height())
+		}
+	}
+	if b.vertical && len(children) > 1 {
+		result += (len(children) - 1) * b.Spacing
+	}
+	return result + b.Padding*2
+}
+
+// Returns the width of the box
+func (b box) Width() int {
+	children := shownChildren(b.children)
+	result := 0
+	for _, child := range children {
+		if b.vertical {
+			result = MaxInt(result, child.Width())
+		} else {
+			result += child.Width()
+		}
+	}
+	if !b.vertical && len(children) > 1 {
+		result += (len(children) - 1) * b.Spacing
+	}
+	return result + b.Padding*2
+}
+
+// A container that stacks the elements from top to bottom
+type VBox struct {
+	*box
+}
+
+// Creates a vertical box. Spacing is the amount of rows between the elements
+func NewVBox(menu Menu, y, x, spacing int) (*VBox, error) {
+	result := VBox{}
+	result.box = createBox(y, x, spacing, true)
+	menu.AddElement(&result)
+	return &result, nil
+}
+
+// Adds the elements to the bottom of the box
+func (v *VBox) Add(elements ...UIElement) error {
+	return v.add(v, elements)
+}
+
+// A container that places the elements from left to right
+type HBox struct {
+	*box
+}
+
+// Creates a horizontal box. Spacing is the amount of columns between the elements
+func NewHBox(menu Menu, y, x, spacing int) (*HBox, error) {
+	result := HBox{}
+	result.box = createBox(y, x, spacing, false)
+	menu.AddElement(&result)
+	return &result, nil
+}
+
+// Adds the elements to the right of the box
+func (h *HBox) Add(elements ...UIElement) error {
+	return h.add(h, elements)
+}
+
+// A child of the grid and its cells
+type gridCell struct {
+	element          UIElement
+	row, col         int
+	rowSpan, colSpan int
+}
+
+// A container that places the elements in the cells of a table.
+// The rows are as high as their highest element, the columns are as wide as their widest element
+type Grid struct {
+	data  *UIElementData
+	cells []gridCell
+	// The space between the border of the grid and the children
+	Padding int
+	// The amount of rows between the rows of the grid
+	RowSpacing int
+	// The amount of columns between the columns of the grid
+	ColSpacing int
+}
+
+// Creates a grid
+func NewGrid(menu Menu, y, x, rowSpacing, colSpacing int) (*Grid, error) {
+	result := Grid{}
+	result.data = createUIED(y, x)
+	result.data.Focusable = false
+	result.RowSpacing = rowSpacing
+	result.ColSpacing = colSpacing
+	result.cells = []gridCell{}
+	menu.AddElement(&result)
+	return &result, nil
+}
+
+// Adds the element to the cell of the grid
+func (g *Grid) Add(element UIElement, row, col int) error {
+	return g.AddSpan(element, row, col, 1, 1)
+}
+
+// Adds the element to the cells of the grid. The element takes rowSpan rows and colSpan columns
+func (g *Grid) AddSpan(element UIElement, row, col, rowSpan, colSpan int) error {
+	if row < 0 || col < 0 || rowSpan < 1 || colSpan < 1 {
+		return fmt.Errorf("termui - can't add element to grid at %v:%v with span %v:%v", row, col, rowSpan, colSpan)
+	}
+	err := adopt(g, element)
+	if err != nil {
+		return err
+	}
+	g.cells = append(g.cells, gridCell{element: element, row: row, col: col, rowSpan: rowSpan, colSpan: colSpan})
+	g.Layout()
+	return nil
+}
+
+// Returns the children of the grid
+func (g Grid) GetChildren() []UIElement {
+	result := make([]UIElement, 0, len(g.cells))
+	for _, cell := range g.cells {
+		result = append(result, cell.element)
+	}
+	return result
+}
+
+// Returns the sizes of the tracks (rows or columns).
+// Spanning elements that don't fit grow the last of their tracks
+func gridTracks(sizes []int, starts []int, spans []int, spacing int) []int {
+	count := 0
+	for i := range sizes {
+		count = MaxInt(count, starts[i]+spans[i])
+	}
+	result := make([]int, count)
+	for i, size := range sizes {
+		if spans[i] == 1 {
+			result[starts[i]] = MaxInt(result[starts[i]], size)
+		}
+	}
+	for i, size := range sizes {
+		if spans[i] == 1 {
+			continue
+		}
+		last := starts[i] + spans[i] - 1
+		spanned := SumInt(result[starts[i]:last+1]...) + (spans[i]-1)*spacing
+		if spanned < size {
+			result[last] += size - spanned
+		}
+	}
+	return result
+}
+
+// Returns the heights of the rows and the widths of the columns
+func (g Grid) tracks() ([]int, []int) {
+	heights, widths := []int{}, []int{}
+	rows, cols := []int{}, []int{}
+	rowSpans, colSpans := []int{}, []int{}
+	for _, cell := range g.cells {
+		if !cell.element.GetElementData().Visible {
+			continue
+		}
+		heights = append(heights, cell.element.Height())
+		widths = append(widths, cell.element.Width())
+		rows = append(rows, cell.row)
+		cols = append(cols, cell.col)
+		rowSpans = append(rowSpans, cell.rowSpan)
+		colSpans = append(colSpans, cell.colSpan)
+	}
+	return gridTracks(heights, rows, rowSpans, g.RowSpacing), gridTracks(widths, cols, colSpans, g.ColSpacing)
+}
+
+// Returns the offset of the track
+func trackOffset(sizes []int, track, spacing int) int {
+	return SumInt(sizes[:track]...) + track*spacing
+}
+
+// Returns the size of all the tracks with the spacing between them
+func tracksLength(sizes []int, spacing int) int {
+	if len(sizes) == 0 {
+		return 0
+	}
+	return SumInt(sizes...) + (len(sizes)-1)*spacing
+}
+
+// Places the children in their cells. Hidden children don't take space
+func (g Grid) Layout() {
+	heights, widths := g.tracks()
+	for _, cell := range g.cells {
+		if !cell.element.GetElementData().Visible {
+			continue
+		}
+		y := g.data.yPos + g.Padding + trackOffset(heights, cell.row, g.RowSpacing)
+		x := g.data.xPos + g.Padding + trackOffset(widths, cell.col, g.ColSpacing)
+		moveElement(cell.element, y, x)
+	}
+}
+
+// Returns the element data of the grid
+func (g Grid) GetElementData() *UIElementData {
+	return g.data
+}
+
+// The grid doesn't draw anything, the children are drawn by the menu
+func (g Grid) Draw(s Surface) error {
+	return nil
+}
+
+// Doesn't handle any keys
+func (g Grid) HandleKey(key Key) (bool, error) {
+	return false, nil
+}
+
+// Returns the height of the grid
+func (g Grid) Height() int {
+	heights, _ := g.tracks()
+	return tracksLength(heights, g.RowSpacing) + g.Padding*2
+}
+
+// Returns the width of the grid
+func (g Grid) Width() int {
+	_, widths := g.tracks()
+	return tracksLength(widths, g.ColSpacing) + g.Padding*2
+}
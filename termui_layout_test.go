@@ -0,0 +1,117 @@
+package termui
+
+import "testing"
+
+// Returns the location of the element relative to the inside of the window border
+func elementYX(element hasElementData) (int, int) {
+	data := element.GetElementData()
+	return data.yPos - yOffset, data.xPos - xOffset
+}
+
+// Fails the test if the element isn't at the location
+func expectYX(t *testing.T, name string, element hasElementData, y, x int) {
+	t.Helper()
+	gotY, gotX := elementYX(element)
+	if gotY != y || gotX != x {
+		t.Errorf("%v is at %v:%v, want %v:%v", name, gotY, gotX, y, x)
+	}
+}
+
+func TestVBox(t *testing.T) {
+	w, _ := newTestWindow(t, 10, 30)
+	menu := w.GetMenu()
+	box, err := NewVBox(menu, 1, 2, 1)
+	must(t, err)
+	labels := []*Label{}
+	for _, text := range []string{"one", "three", "two"} {
+		label, err := NewLabel(menu, 0, 0, text)
+		must(t, err)
+		labels = append(labels, label)
+	}
+	must(t, box.Add(labels[0], labels[1], labels[2]))
+	expectYX(t, "one", labels[0], 1, 2)
+	expectYX(t, "three", labels[1], 3, 2)
+	expectYX(t, "two", labels[2], 5, 2)
+	if box.Height() != 5 || box.Width() != 5 {
+		t.Fatalf("box is %vx%v, want 5x5", box.Height(), box.Width())
+	}
+	// hidden elements don't take space
+	labels[1].GetElementData().Visible = false
+	box.Layout()
+	expectYX(t, "two", labels[2], 3, 2)
+	if box.Height() != 3 || box.Width() != 3 {
+		t.Fatalf("box is %vx%v, want 3x3", box.Height(), box.Width())
+	}
+}
+
+func TestHBoxPadding(t *testing.T) {
+	w, _ := newTestWindow(t, 10, 30)
+	menu := w.GetMenu()
+	box, err := NewHBox(menu, 0, 0, 2)
+	must(t, err)
+	box.Padding = 1
+	labels := []*Label{}
+	for _, text := range []string{"ab", "cde"} {
+		label, err := NewLabel(menu, 0, 0, text)
+		must(t, err)
+		labels = append(labels, label)
+	}
+	must(t, box.Add(labels[0], labels[1]))
+	expectYX(t, "ab", labels[0], 1, 1)
+	expectYX(t, "cde", labels[1], 1, 5)
+	if box.Height() != 3 || box.Width() != 9 {
+		t.Fatalf("box is %vx%v, want 3x9", box.Height(), box.Width())
+	}
+	// the children follow the box when the menu is drawn
+	SetYX(box, 2, 3)
+	must(t, menu.Draw())
+	expectYX(t, "cde", labels[1], 3, 8)
+}
+
+func TestBoxAddErrors(t *testing.T) {
+	w, _ := newTestWindow(t, 10, 30)
+	menu := w.GetMenu()
+	outer, err := NewVBox(menu, 0, 0, 0)
+	must(t, err)
+	inner, err := NewHBox(menu, 0, 0, 0)
+	must(t, err)
+	label, err := NewLabel(menu, 0, 0, "label")
+	must(t, err)
+	must(t, outer.Add(inner))
+	must(t, inner.Add(label))
+	if outer.Add(label) == nil {
+		t.Error("added the element to the second container")
+	}
+	if inner.Add(outer) == nil {
+		t.Error("added the container to its child")
+	}
+}
+
+func TestGrid(t *testing.T) {
+	w, screen := newTestWindow(t, 8, 30)
+	menu := w.GetMenu()
+	grid, err := NewGrid(menu, 0, 0, 0, 1)
+	must(t, err)
+	labels := []*Label{}
+	for _, text := range []string{"Name:", "${green}Alice", "Age:", "30", "${red}a long spanning label"} {
+		label, err := NewLabel(menu, 0, 0, text)
+		must(t, err)
+		labels = append(labels, label)
+	}
+	must(t, grid.Add(labels[0], 0, 0))
+	must(t, grid.Add(labels[1], 0, 1))
+	must(t, grid.Add(labels[2], 1, 0))
+	must(t, grid.Add(labels[3], 1, 1))
+	must(t, grid.AddSpan(labels[4], 2, 0, 1, 2))
+	expectYX(t, "Alice", labels[1], 0, 6)
+	expectYX(t, "30", labels[3], 1, 6)
+	expectYX(t, "spanning", labels[4], 2, 0)
+	// the spanning label grows the second column
+	if grid.Height() != 3 || grid.Width() != 21 {
+		t.Fatalf("grid is %vx%v, want 3x21", grid.Height(), grid.Width())
+	}
+	if grid.AddSpan(labels[0], 0, 0, 0, 1) == nil {
+		t.Error("added the element with an empty span")
+	}
+	matchGolden(t, w, screen, "grid")
+}
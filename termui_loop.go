@@ -0,0 +1,117 @@
+package termui
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// Returned by the dialogs when the window exits while they are open
+	ErrWindowExited = errors.New("termui - window exited")
+)
+
+// Returned by Start when the window panics
+type PanicError struct {
+	// The value passed to panic
+	Value interface{}
+	// The stack of the panicking goroutine
+	Stack []byte
+}
+
+// Returns the panic value and the stack
+func (e PanicError) Error() string {
+	return fmt.Sprintf("termui - window panicked: %v\n%s", e.Value, e.Stack)
+}
+
+// Result of reading a key from the input source
+type keyResult struct {
+	key   Key
+	ok    bool
+	mouse MouseEvent
+}
+
+// Returns the channel that receives the next key of the input source.
+// Starts reading the key if it isn't being read already
+func (w *Window) keyChan() chan keyResult {
+	if w.keys == nil {
+		keys := make(chan keyResult, 1)
+		input := w.input
+		go func() {
+			key, ok := input.NextKey()
+			result := keyResult{key: key, ok: ok}
+			// the mouse event is read before the next key replaces it
+			if source, isSource := input.(MouseSource); isSource && key == KeyMouse {
+				result.mouse = source.LastMouse()
+			}
+			keys <- result
+		}()
+		w.keys = keys
+	}
+	return w.keys
+}
+
+// Waits for the next key and applies the queued updates.
+// If returnOnUpdate is true, returns false after the updates are applied.
+// If the input source is exhausted, stops the window and returns KeyEscape.
+// After the window exits returns KeyEscape
+func (w *Window) waitEvent(returnOnUpdate bool) (Key, bool) {
+	for {
+		if w.applyUpdates() && returnOnUpdate {
+			return 0, false
+		}
+		if w.exited {
+			// the dialogs are closed after the window exits
+			return KeyEscape, true
+		}
+		select {
+		case result := <-w.keyChan():
+			w.keys = nil
+			if !result.ok {
+				w.inputDone = true
+				w.running = false
+				return KeyEscape, true
+			}
+			if result.key == KeyMouse {
+				w.mouse = result.mouse
+			}
+			return result.key, true
+		case <-w.wake:
+		}
+	}
+}
+
+// Queues the update. The update is called on the goroutine that runs the window, after that the window is redrawn.
+//
+// Safe to call from any goroutine
+func (w *Window) QueueUpdate(update func()) {
+	w.updateLock.Lock()
+	w.updates = append(w.updates, update)
+	w.updateLock.Unlock()
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Calls the queued updates. Returns true if there were any
+func (w *Window) applyUpdates() bool {
+	w.updateLock.Lock()
+	updates := w.updates
+	w.updates = nil
+	w.updateLock.Unlock()
+	for _, update := range updates {
+		update()
+	}
+	return len(updates) > 0
+}
+
+// Returns the error the dialogs return when they can't get any more keys
+func (w *Window) closedErr() error {
+	if w.inputDone {
+		return ErrInputExhausted
+	}
+	if w.exited {
+		return ErrWindowExited
+	}
+	return nil
+}
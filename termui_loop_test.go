@@ -0,0 +1,134 @@
+package termui
+
+import (
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Input source that gives the keys sent to the channel and is exhausted when the channel is closed
+type chanInput chan Key
+
+func (c chanInput) NextKey() (Key, bool) {
+	key, ok := <-c
+	return key, ok
+}
+
+// Waits until the screen shows the text
+func waitForText(t *testing.T, screen *VirtualScreen, text string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(screen.String(), text) {
+		if time.Now().After(deadline) {
+			t.Fatalf("the screen doesn't show %q:\n%s", text, screen.String())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestQueueUpdate(t *testing.T) {
+	w, screen := newTestWindow(t, 5, 30)
+	label, err := NewLabel(w.GetMenu(), 1, 1, "start")
+	must(t, err)
+	input := make(chanInput)
+	w.SetInput(input)
+	done := make(chan error)
+	go func() {
+		done <- w.Start()
+	}()
+	waitForText(t, screen, "start")
+	// the window waits for a key, so only the redraw after the update can change the screen
+	for i := 0; i < 3; i++ {
+		text := "update " + strconv.Itoa(i)
+		w.QueueUpdate(func() {
+			// t.Error is safe to call on the goroutine of the window
+			if err := label.SetText(text); err != nil {
+				t.Error(err)
+			}
+		})
+		waitForText(t, screen, text)
+	}
+	order := []int{}
+	applied := make(chan bool)
+	go func() {
+		for i := 0; i < 100; i++ {
+			i := i
+			w.QueueUpdate(func() {
+				order = append(order, i)
+			})
+		}
+		w.QueueUpdate(func() {
+			applied <- strings.Contains(string(debug.Stack()), "(*Window).Start(")
+		})
+	}()
+	if !<-applied {
+		t.Fatal("the update wasn't called by the window")
+	}
+	for i, got := range order {
+		if got != i {
+			t.Fatalf("update %v was called as %v", got, i)
+		}
+	}
+	if len(order) != 100 {
+		t.Fatalf("%v of 100 updates were called", len(order))
+	}
+	close(input)
+	must(t, <-done)
+	// nothing applies the updates after the exit, queueing them doesn't block
+	queued := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			w.QueueUpdate(func() {})
+		}
+		close(queued)
+	}()
+	select {
+	case <-queued:
+	case <-time.After(5 * time.Second):
+		t.Fatal("QueueUpdate blocked after the exit")
+	}
+}
+
+// Returns the panic of the window, fails the test if err isn't a panic
+func panicOf(t *testing.T, err error) *PanicError {
+	t.Helper()
+	result, ok := err.(*PanicError)
+	if !ok {
+		t.Fatalf("got %v, want a panic", err)
+	}
+	return result
+}
+
+func TestPanicInKeyHandler(t *testing.T) {
+	w, screen := newTestWindow(t, 5, 20)
+	button, err := NewButton(w.GetMenu(), 0, 0, "Crash", func() error {
+		panic("crash")
+	}, KeyEnter)
+	must(t, err)
+	w.GetMenu().Focus(button)
+	screen.PushKeys(KeyEnter)
+	p := panicOf(t, w.Start())
+	if p.Value != "crash" || !strings.Contains(string(p.Stack), "TestPanicInKeyHandler") {
+		t.Fatalf("got the panic %v with the stack:\n%s", p.Value, p.Stack)
+	}
+	if !screen.Ended() || !screen.CursorVisible() {
+		t.Fatal("the screen wasn't restored after the panic")
+	}
+}
+
+func TestPanicInUpdate(t *testing.T) {
+	w, screen := newTestWindow(t, 5, 20)
+	w.SetInput(make(chanInput))
+	w.QueueUpdate(func() {
+		panic("update")
+	})
+	p := panicOf(t, w.Start())
+	if p.Value != "update" || !strings.Contains(string(p.Stack), "TestPanicInUpdate") {
+		t.Fatalf("got the panic %v with the stack:\n%s", p.Value, p.Stack)
+	}
+	if !screen.Ended() || !screen.CursorVisible() {
+		t.Fatal("the screen wasn't restored after the panic")
+	}
+}
@@ -0,0 +1,657 @@
+package termui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// An item of the menu bar or of a pull-down menu.
+// The & in the label marks the accelerator letter, the letter is underlined. && is the & itself
+type MenuItem struct {
+	cctLabel *CCTMessage
+	// The lowercase accelerator letter, the letter as written, its column in the label and its color
+	accel      rune
+	accelCh    rune
+	accelPos   int
+	accelColor Attr
+	click      func() error
+	items      []*MenuItem
+	separator  bool
+	checkable  bool
+	checked    bool
+	enabled    bool
+}
+
+// Creates the item with the label
+func newMenuItem(label string) (*MenuItem, error) {
+	result := MenuItem{}
+	result.enabled = true
+	err := result.SetLabel(label)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Creates a menu item. Click is called when the item is picked
+func NewMenuItem(label string, click func() error) (*MenuItem, error) {
+	result, err := newMenuItem(label)
+	if err != nil {
+		return nil, err
+	}
+	result.click = click
+	return result, nil
+}
+
+// Creates a checkable menu item. Picking the item toggles it, then click is called
+func NewCheckMenuItem(label string, checked bool, click func() error) (*MenuItem, error) {
+	result, err := NewMenuItem(label, click)
+	if err != nil {
+		return nil, err
+	}
+	result.checkable = true
+	result.checked = checked
+	return result, nil
+}
+
+// Creates a menu item that opens the pull-down menu with the items
+func NewSubmenu(label string, items ...*MenuItem) (*MenuItem, error) {
+	result, err := newMenuItem(label)
+	if err != nil {
+		return nil, err
+	}
+	result.items = items
+	return result, nil
+}
+
+// Creates a separator line between the items of a pull-down menu
+func NewMenuSeparator() *MenuItem {
+	result := MenuItem{}
+	result.separator = true
+	result.accelPos = -1
+	return &result
+}
+
+// Adds the items to the pull-down menu of the item
+func (i *MenuItem) Add(items ...*MenuItem) {
+	i.items = append(i.items, items...)
+}
+
+// Sets the label of the item
+func (i *MenuItem) SetLabel(label string) error {
+	cct, err := ToCCTMessage(label)
+	if err != nil {
+		return err
+	}
+	i.cctLabel = cct
+	i.accelCh, i.accelPos, i.accelColor = parseAccelerator(cct)
+	i.accel = unicode.ToLower(i.accelCh)
+	return nil
+}
+
+// Enables or disables the item. A disabled item is dimmed and can't be picked
+func (i *MenuItem) SetEnabled(enabled bool) {
+	i.enabled = enabled
+}
+
+// Returns true if the item is enabled
+func (i MenuItem) IsEnabled() bool {
+	return i.enabled
+}
+
+// Checks or unchecks the item
+func (i *MenuItem) SetChecked(checked bool) {
+	i.checked = checked
+}
+
+// Returns true if the item is checked
+func (i MenuItem) IsChecked() bool {
+	return i.checked
+}
+
+// Removes the & markers from the label.
+// Returns the accelerator letter, its column in the label and its color. The column is -1 if there is no accelerator
+func parseAccelerator(cct *CCTMessage) (rune, int, Attr) {
+	accel, pos, color := rune(0), -1, AttrNormal
+	offset := 0
+	for i, s := range cct.strings {
+		runes := []rune(s)
+		result := []rune{}
+		for j := 0; j < len(runes); j++ {
+			if runes[j] == '&' && j+1 < len(runes) {
+				j++
+				if runes[j] != '&' && pos == -1 {
+					accel = runes[j]
+					pos = offset + len(result)
+					color = cct.colors[i]
+				}
+			}
+			result = append(result, runes[j])
+		}
+		cct.strings[i] = string(result)
+		// every rune takes a cell
+		offset += len(result)
+	}
+	return accel, pos, color
+}
+
+// Draws the label with the underlined accelerator
+func (i MenuItem) drawLabel(s Surface, y, x int, attr ...Attr) {
+	i.cctLabel.Draw(s, y, x, attr...)
+	if i.accelPos != -1 {
+		Put(s, y, x+i.accelPos, string(i.accelCh), append(attr, i.accelColor, AttrUnderline)...)
+	}
+}
+
+// Returns true if the item can be picked
+func (i MenuItem) pickable() bool {
+	return !i.separator && i.enabled
+}
+
+// A row of a pull-down menu
+type menuLine struct {
+	item        *MenuItem
+	width       int
+	checkColumn bool
+	border      Attr
+}
+
+// Draws the item on the whole row. A disabled item is dimmed
+func (l menuLine) Draw(s Surface, y, x int, attr ...Attr) {
+	if l.item.separator {
+		for i := 0; i < l.width; i++ {
+			s.SetCell(y, x+i, runeHLine, l.border)
+		}
+		return
+	}
+	if !l.item.enabled {
+		attr = append(attr, AttrDim)
+	}
+	Put(s, y, x, strings.Repeat(" ", l.width), attr...)
+	pos := x + 1
+	if l.checkColumn {
+		if l.item.checked {
+			Put(s, y, pos, "x", attr...)
+		}
+		pos += 2
+	}
+	l.item.drawLabel(s, y, pos, attr...)
+	if len(l.item.items) > 0 {
+		Put(s, y, x+l.width-2, ">", attr...)
+	}
+}
+
+// Returns the length of the label with the check column and the submenu arrow
+func (l menuLine) Length() int {
+	if l.item.separator {
+		return 0
+	}
+	result := 1 + l.item.cctLabel.Length() + 3
+	if l.checkColumn {
+		result += 2
+	}
+	return result
+}
+
+// A pull-down menu, shown as a modal layer of the window
+type menuPopup struct {
+	bar           *MenuBar
+	parent        *menuPopup
+	child         *menuPopup
+	items         []*MenuItem
+	lt            *ListTemplate
+	y, x          int
+	height, width int
+}
+
+// Creates the pull-down menu with the top left corner at the location. The menu is moved to fit the window
+func newMenuPopup(bar *MenuBar, parent *menuPopup, items []*MenuItem, y, x int) (*menuPopup, error) {
+	border, err := ParseColorPair(bar.bcolor)
+	if err != nil {
+		return nil, err
+	}
+	result := menuPopup{}
+	result.bar = bar
+	result.parent = parent
+	result.items = items
+	checkColumn := false
+	for _, item := range items {
+		checkColumn = checkColumn || item.checkable
+	}
+	lines := make([]menuLine, 0, len(items))
+	innerWidth := 0
+	for _, item := range items {
+		line := menuLine{item: item, checkColumn: checkColumn, border: border}
+		innerWidth = MaxInt(innerWidth, line.Length())
+		lines = append(lines, line)
+	}
+	options := make([]DrawableAsLine, 0, len(lines))
+	for _, line := range lines {
+		line.width = innerWidth
+		options = append(options, line)
+	}
+	height, width := bar.window().GetMaxYX()
+	result.width = innerWidth + 2
+	result.height = MaxInt(MinInt(len(items), height-y-2), 1) + 2
+	result.y = y
+	result.x = MaxInt(MinInt(x, width-result.width), 0)
+	result.lt = CreateListTemplate(options, result.height-2)
+	if !items[0].pickable() {
+		result.move(true)
+	}
+	return &result, nil
+}
+
+// Returns the selected item
+func (p menuPopup) selected() *MenuItem {
+	return p.items[p.lt.choice]
+}
+
+// Moves the cursor to the next item that isn't a separator
+func (p *menuPopup) move(down bool) {
+	for range p.items {
+		if down {
+			p.lt.ScrollDown()
+		} else {
+			p.lt.ScrollUp()
+		}
+		if !p.selected().separator {
+			return
+		}
+	}
+}
+
+// Draws the pull-down menu over the window
+func (p menuPopup) Draw(s Surface) error {
+	clearArea(s, p.y, p.x, p.height, p.width)
+	err := DrawBox(s, p.y, p.x, p.height, p.width, p.bar.bcolor)
+	if err != nil {
+		return err
+	}
+	border, err := ParseColorPair(p.bar.bcolor)
+	if err != nil {
+		return err
+	}
+	err = p.lt.Draw(s, p.y+1, p.x+1, true)
+	if err != nil {
+		return err
+	}
+	rows := p.height - 2
+	for i := 0; i < MinInt(rows, len(p.items)); i++ {
+		if p.items[p.lt.pageN+i].separator {
+			s.SetCell(p.y+1+i, p.x, runeLTee, border)
+			s.SetCell(p.y+1+i, p.x+p.width-1, runeRTee, border)
+		}
+	}
+	if len(p.items) > rows {
+		if p.lt.pageN != 0 {
+			s.SetCell(p.y+1, p.x+p.width-1, runeUArrow, border)
+		}
+		if p.lt.pageN != len(p.items)-rows {
+			s.SetCell(p.y+p.height-2, p.x+p.width-1, runeDArrow, border)
+		}
+	}
+	return nil
+}
+
+// Opens the pull-down menu of the selected item to the right of the menu
+func (p *menuPopup) openChild() error {
+	child, err := newMenuPopup(p.bar, p, p.selected().items, p.y+1+p.lt.cursor, p.x+p.width)
+	if err != nil {
+		return err
+	}
+	p.child = child
+	p.bar.window().PushLayer(child, true)
+	return nil
+}
+
+// Closes the menu and its submenus. Closing the top pull-down menu closes the menu bar
+func (p *menuPopup) close() {
+	if p.child != nil {
+		p.child.close()
+	}
+	p.bar.window().RemoveLayer(p)
+	if p.parent != nil {
+		p.parent.child = nil
+		return
+	}
+	p.bar.popup = nil
+	p.bar.opened = -1
+}
+
+// Picks the selected item: opens its submenu or closes the menu bar and calls the item
+func (p *menuPopup) pick() error {
+	item := p.selected()
+	if !item.pickable() {
+		return nil
+	}
+	if len(item.items) > 0 {
+		return p.openChild()
+	}
+	p.bar.close()
+	return item.activate()
+}
+
+// Selects the item with the accelerator and picks it. Returns false if there is no such item
+func (p *menuPopup) pickAccelerator(ch rune) (bool, error) {
+	for i, item := range p.items {
+		if item.accelPos == -1 || item.accel != ch || !item.pickable() {
+			continue
+		}
+		for p.lt.choice != i {
+			p.lt.ScrollDown()
+		}
+		return true, p.pick()
+	}
+	return false, nil
+}
+
+// On up/down moves the cursor, on enter picks the item, on escape closes the menu.
+// Right opens the submenu or the next pull-down menu of the bar, left closes the submenu or opens the previous pull-down menu.
+// The accelerator letters (with or without alt) pick the items, alt + the accelerators of the bar open its pull-down menus.
+// The keys don't go to the window while the menu is open
+func (p *menuPopup) HandleKey(key Key) (bool, error) {
+	switch key {
+	case KeyUp:
+		p.move(false)
+	case KeyDown:
+		p.move(true)
+	case KeyEnter:
+		return true, p.pick()
+	case KeyEscape:
+		p.close()
+	case KeyRight:
+		if item := p.selected(); item.pickable() && len(item.items) > 0 {
+			return true, p.openChild()
+		}
+		return true, p.bar.step(1)
+	case KeyLeft:
+		if p.parent != nil {
+			p.close()
+			return true, nil
+		}
+		return true, p.bar.step(-1)
+	case KeyF10:
+		p.bar.close()
+	case KeyMouse:
+		return true, p.handleMouse(p.bar.window().GetMouse())
+	default:
+		ch := unicode.ToLower(rune(key &^ KeyAlt))
+		picked, err := p.pickAccelerator(ch)
+		if picked || err != nil || key&KeyAlt == 0 {
+			return true, err
+		}
+		return true, p.bar.openAccelerator(ch)
+	}
+	return true, nil
+}
+
+// Returns true if the point is inside the menu
+func (p menuPopup) contains(y, x int) bool {
+	return y >= p.y && y < p.y+p.height && x >= p.x && x < p.x+p.width
+}
+
+// The mouse wheel moves the cursor, a click picks the item.
+// A click on the menus below closes the menu and goes to them, a click on the bar goes to the bar, a click elsewhere closes the bar
+func (p *menuPopup) handleMouse(event MouseEvent) error {
+	switch {
+	case event.Button == MouseWheelUp:
+		p.move(false)
+		return nil
+	case event.Button == MouseWheelDown:
+		p.move(true)
+		return nil
+	case !event.IsClick():
+		return nil
+	case p.contains(event.Y, event.X):
+		if !p.lt.SelectRow(event.Y-p.y-1) || p.selected().separator {
+			return nil
+		}
+		if p.child != nil {
+			p.child.close()
+		}
+		return p.pick()
+	case p.parent != nil:
+		p.close()
+		return p.parent.handleMouse(event)
+	}
+	bar := p.bar.data
+	if event.Y == bar.yPos && event.X >= bar.xPos && event.X < bar.xPos+p.bar.width {
+		event.Y -= bar.yPos
+		event.X -= bar.xPos
+		return p.bar.HandleMouse(event)
+	}
+	p.bar.close()
+	return nil
+}
+
+// A menu bar pinned to the top row of the menu, inside the border. The items of the bar open their pull-down menus.
+// Alt + the accelerator of an item or a click opens its pull-down menu, F10 opens the first one.
+// The bar should be created after the elements below it, so that it's drawn above them
+type MenuBar struct {
+	data   *UIElementData
+	parent ParentGetter
+	keys   *KeyBindings
+	items  []*MenuItem
+	width  int
+	bcolor string
+	popup  *menuPopup
+	opened int
+}
+
+// Creates a menu bar. The pull-down menus are drawn with the border color.
+// The keys of the bar are bound in the menu and the popups are shown in the window of the menu,
+// so the menu has to be Bindable and ParentGetter
+func NewMenuBar(menu Menu, borderColor string) (*MenuBar, error) {
+	_, err := ParseColorPair(borderColor)
+	if err != nil {
+		return nil, err
+	}
+	bindable, ok := menu.(Bindable)
+	if !ok {
+		return nil, fmt.Errorf("termui - MenuBar needs a menu with key bindings")
+	}
+	parent, ok := menu.(ParentGetter)
+	if !ok {
+		return nil, fmt.Errorf("termui - MenuBar needs a menu that returns its window")
+	}
+	result := MenuBar{}
+	result.data = createUIED(0, 0)
+	result.data.Focusable = false
+	result.parent = parent
+	result.keys = bindable.GetBindings()
+	result.items = []*MenuItem{}
+	result.bcolor = borderColor
+	result.opened = -1
+	err = result.keys.Bind("f10", func() error {
+		return result.step(1)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if window := parent.GetParent(); window != nil {
+		_, width := window.GetMaxYX()
+		result.OnResize(0, width)
+	}
+	menu.AddElement(&result)
+	return &result, nil
+}
+
+// Adds the items to the bar. The alt + accelerator keys of the items are bound in the menu.
+// An item without a pull-down menu is called right away
+func (b *MenuBar) Add(items ...*MenuItem) error {
+	for _, item := range items {
+		if item.separator {
+			return fmt.Errorf("termui - can't add a separator to MenuBar")
+		}
+		if item.accelPos == -1 {
+			continue
+		}
+		ch := item.accel
+		err := b.keys.Bind(fmt.Sprintf("alt+%c", ch), func() error {
+			return b.openAccelerator(ch)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	b.items = append(b.items, items...)
+	return nil
+}
+
+// Returns the items of the bar
+func (b MenuBar) GetItems() []*MenuItem {
+	return b.items
+}
+
+// Returns the window of the menu of the bar
+func (b MenuBar) window() *Window {
+	return b.parent.GetParent()
+}
+
+// Returns true if a pull-down menu of the bar is open
+func (b MenuBar) IsOpen() bool {
+	return b.popup != nil
+}
+
+// Opens the pull-down menu of the item. An item without a pull-down menu is called
+func (b *MenuBar) Open(i int) error {
+	if i < 0 || i >= len(b.items) {
+		return fmt.Errorf("termui - MenuBar has no item %d", i)
+	}
+	b.close()
+	item := b.items[i]
+	if !item.enabled || !b.data.Enabled || !isShown(b) {
+		return nil
+	}
+	if len(item.items) == 0 {
+		return item.activate()
+	}
+	if b.window() == nil {
+		return fmt.Errorf("termui - MenuBar is not in a window")
+	}
+	popup, err := newMenuPopup(b, nil, item.items, b.data.yPos+1, b.titleX(i)-1)
+	if err != nil {
+		return err
+	}
+	b.popup = popup
+	b.opened = i
+	b.window().PushLayer(popup, true)
+	return nil
+}
+
+// Opens the pull-down menu of the item with the accelerator
+func (b *MenuBar) openAccelerator(ch rune) error {
+	for i, item := range b.items {
+		if item.accelPos != -1 && item.accel == ch {
+			return b.Open(i)
+		}
+	}
+	return nil
+}
+
+// Opens the pull-down menu of the next or the previous item with a pull-down menu
+func (b *MenuBar) step(delta int) error {
+	for i, j := 0, b.opened; i < len(b.items); i++ {
+		j = (j + delta + len(b.items)) % len(b.items)
+		if item := b.items[j]; item.enabled && len(item.items) > 0 {
+			return b.Open(j)
+		}
+	}
+	return nil
+}
+
+// Closes the open pull-down menu
+func (b *MenuBar) close() {
+	if b.popup != nil {
+		b.popup.close()
+	}
+}
+
+// Toggles the checkable item and calls it
+func (i *MenuItem) activate() error {
+	if i.checkable {
+		i.checked = !i.checked
+	}
+	if i.click == nil {
+		return nil
+	}
+	return i.click()
+}
+
+// Returns the column of the label of the item
+func (b MenuBar) titleX(i int) int {
+	x := b.data.xPos + 1
+	for _, item := range b.items[:i] {
+		x += item.cctLabel.Length() + 2
+	}
+	return x + 1
+}
+
+// Returns the element data of the bar
+func (b MenuBar) GetElementData() *UIElementData {
+	return b.data
+}
+
+// Draws the bar in reverse, the item of the open pull-down menu isn't reversed. A disabled bar is dimmed
+func (b MenuBar) Draw(s Surface) error {
+	s = elementSurface(s, b.data)
+	y := b.data.yPos
+	Put(s, y, b.data.xPos, strings.Repeat(" ", b.width), AttrReverse)
+	for i, item := range b.items {
+		x := b.titleX(i)
+		attr := []Attr{AttrReverse}
+		if i == b.opened {
+			attr = []Attr{AttrNormal}
+		}
+		if !item.enabled {
+			attr = append(attr, AttrDim)
+		}
+		Put(s, y, x-1, strings.Repeat(" ", item.cctLabel.Length()+2), attr...)
+		item.drawLabel(s, y, x, attr...)
+	}
+	return nil
+}
+
+// Doesn't handle any keys. The keys are handled by the bindings of the menu and by the open pull-down menus
+func (b MenuBar) HandleKey(key Key) (bool, error) {
+	return false, nil
+}
+
+// A click on the item opens its pull-down menu, a click on the item of the open pull-down menu closes it
+func (b *MenuBar) HandleMouse(event MouseEvent) error {
+	if !event.IsClick() || event.Y != 0 {
+		return nil
+	}
+	for i, item := range b.items {
+		x := b.titleX(i) - b.data.xPos
+		if event.X < x-1 || event.X > x+item.cctLabel.Length() {
+			continue
+		}
+		if i == b.opened {
+			b.close()
+			return nil
+		}
+		return b.Open(i)
+	}
+	b.close()
+	return nil
+}
+
+// Keeps the bar on the top row, as wide as the inside of the border
+func (b *MenuBar) OnResize(height, width int) {
+	b.data.yPos = yOffset
+	b.data.xPos = xOffset
+	b.width = MaxInt(width-2, 0)
+}
+
+// Returns 1
+func (b MenuBar) Height() int {
+	return 1
+}
+
+// Returns the width of the inside of the border
+func (b MenuBar) Width() int {
+	return b.width
+}
@@ -0,0 +1,94 @@
+package termui
+
+import "testing"
+
+func TestParseAccelerator(t *testing.T) {
+	tests := []struct {
+		label string
+		raw   string
+		accel rune
+		pos   int
+	}{
+		{"&File", "File", 'F', 0},
+		{"E&xit", "Exit", 'x', 1},
+		{"Save && &quit", "Save & quit", 'q', 7},
+		{"No accelerator&", "No accelerator&", 0, -1},
+		{"Фа&йл", "Файл", 'й', 2},
+		{"${red}Ж${normal}&Open", "ЖOpen", 'O', 1},
+		{"&a &b", "a b", 'a', 0},
+	}
+	for _, tt := range tests {
+		cct, err := ToCCTMessage(tt.label)
+		must(t, err)
+		accel, pos, _ := parseAccelerator(cct)
+		if raw := cct.ToRawString(); raw != tt.raw || accel != tt.accel || pos != tt.pos {
+			t.Errorf("%q: got %q, %q at %v, want %q, %q at %v", tt.label, raw, accel, pos, tt.raw, tt.accel, tt.pos)
+		}
+	}
+}
+
+func TestMenuBar(t *testing.T) {
+	w, screen := newTestWindow(t, 10, 30)
+	// the log records the picked items
+	log := []string{}
+	record := func(name string) func() error {
+		return func() error {
+			log = append(log, name)
+			return nil
+		}
+	}
+	bar, err := NewMenuBar(w.GetMenu(), "normal")
+	must(t, err)
+	open, err := NewMenuItem("&Open", record("open"))
+	must(t, err)
+	exit, err := NewMenuItem("E&xit", record("exit"))
+	must(t, err)
+	disabled, err := NewMenuItem("&Disabled", record("disabled"))
+	must(t, err)
+	disabled.SetEnabled(false)
+	file, err := NewSubmenu("&Файл", open, disabled, NewMenuSeparator(), exit)
+	must(t, err)
+	wrap, err := NewCheckMenuItem("&Wrap", false, record("wrap"))
+	must(t, err)
+	zoom, err := NewMenuItem("&In", record("zoom in"))
+	must(t, err)
+	zoomMenu, err := NewSubmenu("&Zoom", zoom)
+	must(t, err)
+	view, err := NewSubmenu("&View", wrap, zoomMenu)
+	must(t, err)
+	must(t, bar.Add(file, view))
+	matchGolden(t, w, screen, "menubar")
+	must(t, bar.Open(0))
+	matchGolden(t, w, screen, "menubar_open")
+
+	keys := []Key{
+		KeyEscape,
+		// the disabled item can't be picked, the separator is skipped
+		KeyAlt | 'ф', KeyDown, KeyEnter, KeyDown, KeyEnter,
+		KeyF10, KeyRight, 'w',
+		KeyAlt | 'v', KeyDown, KeyRight, 'i',
+		KeyF10, KeyEscape,
+	}
+	for _, key := range keys {
+		must(t, w.dispatchKey(key))
+	}
+	if len(log) != 3 || log[0] != "exit" || log[1] != "wrap" || log[2] != "zoom in" {
+		t.Fatalf("got picks %v", log)
+	}
+	if !wrap.IsChecked() {
+		t.Fatal("the checkable item wasn't toggled")
+	}
+	if bar.IsOpen() || len(w.layers) != 0 {
+		t.Fatal("the pull-down menu is still open")
+	}
+}
+
+func TestMenuBarNeedsBindableMenu(t *testing.T) {
+	w, _ := newTestWindow(t, 10, 30)
+	menu, err := NewNormalMenu("Plain")
+	must(t, err)
+	w.SetMenu(minimalMenu{menu})
+	if _, err := NewMenuBar(w.GetMenu(), "normal"); err == nil {
+		t.Fatal("created the menu bar in the menu without key bindings")
+	}
+}
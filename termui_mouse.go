@@ -0,0 +1,115 @@
+package termui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A mouse button
+type MouseButton int
+
+const (
+	MouseNone MouseButton = iota
+	MouseLeft
+	MouseMiddle
+	MouseRight
+	MouseWheelUp
+	MouseWheelDown
+)
+
+// What happened to the mouse button
+type MouseAction int
+
+const (
+	MousePress MouseAction = iota
+	MouseRelease
+	MouseMove
+)
+
+var (
+	// Names of the mouse events, used in key scripts
+	mouseNames = map[string]MouseEvent{
+		"Press":      {Button: MouseLeft, Action: MousePress},
+		"Release":    {Button: MouseLeft, Action: MouseRelease},
+		"Drag":       {Button: MouseLeft, Action: MouseMove},
+		"RightPress": {Button: MouseRight, Action: MousePress},
+		"WheelUp":    {Button: MouseWheelUp, Action: MousePress},
+		"WheelDown":  {Button: MouseWheelDown, Action: MousePress},
+	}
+)
+
+// A mouse event. Read with Window.GetMouse after KeyMouse is received
+type MouseEvent struct {
+	// Location of the pointer
+	Y, X   int
+	Button MouseButton
+	Action MouseAction
+}
+
+// Returns true if the event is a press of the left button
+func (e MouseEvent) IsClick() bool {
+	return e.Button == MouseLeft && e.Action == MousePress
+}
+
+// Implemented by the screens and input sources that report mouse events
+type MouseSource interface {
+	// Returns the mouse event of the last KeyMouse
+	LastMouse() MouseEvent
+}
+
+// Implemented by the elements that react to the mouse.
+// Only these elements are focused on click
+type MouseHandler interface {
+	// Called with the mouse events over the element. The location of the event is relative to the element
+	HandleMouse(event MouseEvent) error
+}
+
+// Returns the name of the mouse event, as used in key scripts
+func mouseEventName(event MouseEvent) string {
+	for name, e := range mouseNames {
+		if e.Button == event.Button && e.Action == event.Action {
+			return fmt.Sprintf("%v(%v,%v)", name, event.Y, event.X)
+		}
+	}
+	return fmt.Sprintf("<%v>", KeyMouse)
+}
+
+// Parses the mouse token of the key script, like Press(3,5). Click(3,5) is a press followed by a release
+func parseMouseToken(token string) ([]MouseEvent, bool, error) {
+	open := strings.IndexRune(token, '(')
+	if open == -1 || !strings.HasSuffix(token, ")") {
+		return nil, false, nil
+	}
+	name := token[:open]
+	event, has := MouseEvent{}, false
+	for n, e := range mouseNames {
+		if strings.EqualFold(n, name) {
+			event, has = e, true
+		}
+	}
+	isClick := strings.EqualFold(name, "click")
+	if !has && !isClick {
+		return nil, false, nil
+	}
+	coords := strings.Split(token[open+1:len(token)-1], ",")
+	if len(coords) != 2 {
+		return nil, true, fmt.Errorf("termui - %v must have two coordinates", token)
+	}
+	y, err := strconv.Atoi(strings.TrimSpace(coords[0]))
+	if err != nil {
+		return nil, true, fmt.Errorf("termui - %v has an invalid y coordinate", token)
+	}
+	x, err := strconv.Atoi(strings.TrimSpace(coords[1]))
+	if err != nil {
+		return nil, true, fmt.Errorf("termui - %v has an invalid x coordinate", token)
+	}
+	if isClick {
+		press := MouseEvent{Y: y, X: x, Button: MouseLeft, Action: MousePress}
+		release := MouseEvent{Y: y, X: x, Button: MouseLeft, Action: MouseRelease}
+		return []MouseEvent{press, release}, true, nil
+	}
+	event.Y = y
+	event.X = x
+	return []MouseEvent{event}, true, nil
+}
@@ -0,0 +1,38 @@
+package termui
+
+import "testing"
+
+func TestClickButtonBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		y, x    int
+		clicked bool
+	}{
+		{"first column", 3, 4, true},
+		{"last column", 3, 7, true},
+		{"after the last column", 3, 8, false},
+		{"before the first column", 3, 3, false},
+		{"row above", 2, 5, false},
+		{"row below", 4, 5, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, screen := newTestWindow(t, 6, 20)
+			clicked := false
+			// the menu coordinates start inside the border, the button takes the cells 3,4 to 3,7 of the screen
+			button, err := NewButton(w.GetMenu(), 2, 3, "Done", func() error {
+				clicked = true
+				return nil
+			}, KeyEnter)
+			must(t, err)
+			if button.Width() != 4 || button.Height() != 1 {
+				t.Fatalf("the button is %vx%v, want 1x4", button.Height(), button.Width())
+			}
+			screen.PushMouse(MouseEvent{Y: tt.y, X: tt.x, Button: MouseLeft, Action: MousePress})
+			must(t, w.Start())
+			if clicked != tt.clicked {
+				t.Fatalf("the click at %v,%v activated the button: %v", tt.y, tt.x, clicked)
+			}
+		})
+	}
+}
@@ -0,0 +1,347 @@
+//go:build !termui_pure
+
+package termui
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	nc "github.com/rthornton128/goncurses"
+)
+
+const (
+	// Time in milliseconds the key reader holds goncurses while waiting for a key
+	ncKeyTimeout = 10
+	// BUTTON5_PRESSED of the version 2 mouse interface, goncurses doesn't define it
+	ncButton5Pressed nc.MouseButton = 0x200000
+)
+
+var (
+	// Line drawing characters of curses
+	acsRunes = map[rune]nc.Char{
+		runeULCorner: nc.ACS_ULCORNER,
+		runeURCorner: nc.ACS_URCORNER,
+		runeLLCorner: nc.ACS_LLCORNER,
+		runeLRCorner: nc.ACS_LRCORNER,
+		runeHLine:    nc.ACS_HLINE,
+		runeVLine:    nc.ACS_VLINE,
+		runeLTee:     nc.ACS_LTEE,
+		runeRTee:     nc.ACS_RTEE,
+		runeUArrow:   nc.ACS_UARROW,
+		runeDArrow:   nc.ACS_DARROW,
+		runeLArrow:   nc.ACS_LARROW,
+		runeRArrow:   nc.ACS_RARROW,
+		runeBlock:    nc.ACS_BLOCK,
+	}
+	// Text attributes of curses
+	ncAttrs = map[Attr]nc.Char{
+		AttrReverse:   nc.A_REVERSE,
+		AttrBold:      nc.A_BOLD,
+		AttrUnderline: nc.A_UNDERLINE,
+		AttrDim:       nc.A_DIM,
+	}
+	// Mouse events of curses
+	ncMouseEvents = []struct {
+		mask   nc.MouseButton
+		button MouseButton
+		action MouseAction
+	}{
+		{nc.M_B1_PRESSED, MouseLeft, MousePress},
+		{nc.M_B1_RELEASED, MouseLeft, MouseRelease},
+		{nc.M_B2_PRESSED, MouseMiddle, MousePress},
+		{nc.M_B2_RELEASED, MouseMiddle, MouseRelease},
+		{nc.M_B3_PRESSED, MouseRight, MousePress},
+		{nc.M_B3_RELEASED, MouseRight, MouseRelease},
+		{nc.M_B4_PRESSED, MouseWheelUp, MousePress},
+		{ncButton5Pressed, MouseWheelDown, MousePress},
+	}
+)
+
+// Screen backed by goncurses.
+// goncurses isn't thread safe, so every call is made while holding the lock
+type NcursesScreen struct {
+	win       *nc.Window
+	input     *nc.Window
+	lock      sync.Mutex
+	ended     bool
+	winch     chan os.Signal
+	resized   int32
+	mouse     MouseEvent
+	pressed   MouseButton
+	suspended bool
+}
+
+// Initializes goncurses and creates the screen (should only be called once)
+func NewNcursesScreen() (*NcursesScreen, error) {
+	result := NcursesScreen{}
+	var err error
+	result.win, err = nc.Init()
+	if err != nil {
+		return nil, err
+	}
+	// the terminal is restored if the rest of the setup fails
+	// keys are read from a separate window, so that reading doesn't refresh the half drawn screen
+	result.input, err = nc.NewWindow(1, 1, 0, 0)
+	if err != nil {
+		nc.End()
+		return nil, err
+	}
+	err = nc.StartColor()
+	if err != nil {
+		nc.End()
+		return nil, err
+	}
+	err = nc.UseDefaultColors()
+	if err != nil {
+		nc.End()
+		return nil, err
+	}
+	result.config()
+	result.watchResize()
+	return &result, nil
+}
+
+// Creates the goncurses screen
+func newDefaultScreen() (Screen, error) {
+	return NewNcursesScreen()
+}
+
+// Basic goncurses configuration
+func (s *NcursesScreen) config() {
+	// remove the delay from pressing the escape key
+	nc.SetEscDelay(0)
+	s.win.Keypad(true)
+	s.input.Keypad(true)
+	s.input.Timeout(ncKeyTimeout)
+	s.input.Refresh()
+
+	nc.Raw(true)
+	nc.Echo(false)
+	nc.CBreak(true)
+	// presses and releases are reported separately, instead of clicks
+	nc.MouseInterval(0)
+
+	var mask nc.MouseButton = nc.M_POSITION
+	for _, event := range ncMouseEvents {
+		mask |= event.mask
+	}
+	nc.MouseMask(mask, nil)
+}
+
+// Converts the attribute to the goncurses attribute
+func toNcAttr(attr Attr) nc.Char {
+	result := nc.ColorPair(attr.Pair())
+	for a, ncAttr := range ncAttrs {
+		if attr&a != 0 {
+			result |= ncAttr
+		}
+	}
+	return result
+}
+
+// Returns the goncurses window
+func (s *NcursesScreen) GetWin() *nc.Window {
+	return s.win
+}
+
+// Applies the size change of the terminal, if there was any. Should be called while holding the lock
+func (s *NcursesScreen) applyResize() {
+	if atomic.SwapInt32(&s.resized, 0) == 1 {
+		// goncurses doesn't receive the signal, the size is updated manually
+		nc.End()
+		s.win.Refresh()
+	}
+}
+
+// Returns the height and width of the screen
+func (s *NcursesScreen) MaxYX() (int, int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.applyResize()
+	return s.win.MaxYX()
+}
+
+// Puts the character with the attribute at the location
+func (s *NcursesScreen) SetCell(y, x int, ch rune, attr Attr) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	a := toNcAttr(attr)
+	if acs, has := acsRunes[ch]; has {
+		s.win.MoveAddChar(y, x, acs|a)
+		return
+	}
+	if ch < 128 {
+		s.win.MoveAddChar(y, x, nc.Char(ch)|a)
+		return
+	}
+	s.win.AttrOn(a)
+	s.win.MovePrint(y, x, string(ch))
+	s.win.AttrOff(a)
+}
+
+// Clears the screen
+func (s *NcursesScreen) Erase() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.applyResize()
+	s.win.Erase()
+}
+
+// Refreshes the goncurses window
+func (s *NcursesScreen) Refresh() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.win.Refresh()
+}
+
+// Moves the cursor to the location
+func (s *NcursesScreen) MoveCursor(y, x int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.win.Move(y, x)
+}
+
+// Shows or hides the cursor
+func (s *NcursesScreen) SetCursorVisible(visible bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if visible {
+		nc.Cursor(1)
+		return
+	}
+	nc.Cursor(0)
+}
+
+// Waits for GetChar result. goncurses is released between the tries.
+// After the screen is ended returns KeyEscape
+func (s *NcursesScreen) GetKey() Key {
+	for {
+		s.lock.Lock()
+		if s.ended {
+			s.lock.Unlock()
+			return KeyEscape
+		}
+		if s.suspended {
+			// reading would put the terminal back into curses mode
+			s.lock.Unlock()
+			time.Sleep(ncKeyTimeout * time.Millisecond)
+			continue
+		}
+		select {
+		case <-s.winch:
+			// the size is updated on the drawing goroutine
+			atomic.StoreInt32(&s.resized, 1)
+			s.lock.Unlock()
+			return KeyResize
+		default:
+		}
+		key := s.input.GetChar()
+		if key == nc.KEY_MOUSE {
+			// the event has to be taken from goncurses before the next key
+			s.readMouse()
+		}
+		result := ncExtendedKey(Key(key))
+		if key == nc.KEY_ESC {
+			// escape followed by a character is sent by alt + the character
+			next := s.input.GetChar()
+			switch {
+			case next >= ' ' && next < 127:
+				result = KeyAlt | Key(next)
+			case next != 0:
+				nc.UnGetChar(nc.Char(next))
+			}
+		}
+		s.lock.Unlock()
+		if key != 0 {
+			return result
+		}
+	}
+}
+
+// Reads the mouse event of KEY_MOUSE from goncurses
+func (s *NcursesScreen) readMouse() {
+	md := nc.GetMouse()
+	if md == nil {
+		return
+	}
+	s.mouse = MouseEvent{Y: md.Y, X: md.X, Button: s.pressed, Action: MouseMove}
+	for _, event := range ncMouseEvents {
+		if md.State&event.mask == 0 {
+			continue
+		}
+		s.mouse.Button = event.button
+		s.mouse.Action = event.action
+		switch {
+		case event.action == MouseRelease:
+			s.pressed = MouseNone
+		case event.button != MouseWheelUp && event.button != MouseWheelDown:
+			s.pressed = event.button
+		}
+		return
+	}
+}
+
+// Returns the mouse event of the last KeyMouse
+func (s *NcursesScreen) LastMouse() MouseEvent {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.mouse
+}
+
+// Calls the goncurses InitPair method
+func (s *NcursesScreen) InitPair(pair, fg, bg int16) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return nc.InitPair(pair, fg, bg)
+}
+
+// Calls the goncurses Beep method
+func (s *NcursesScreen) Beep() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	nc.Beep()
+}
+
+// Calls the goncurses Flash method
+func (s *NcursesScreen) Flash() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	nc.Flash()
+}
+
+// Restores the modes of the terminal until Resume is called
+func (s *NcursesScreen) Suspend() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.ended || s.suspended {
+		return
+	}
+	s.suspended = true
+	nc.End()
+}
+
+// Puts the terminal back into curses mode. The screen is redrawn after KeyResize
+func (s *NcursesScreen) Resume() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.ended {
+		return
+	}
+	s.suspended = false
+	s.win.Refresh()
+	// the size could have changed while the process was stopped
+	s.notifyResize()
+}
+
+// Ends goncurses. Does nothing after the first call
+func (s *NcursesScreen) End() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.ended {
+		return
+	}
+	s.ended = true
+	nc.End()
+}
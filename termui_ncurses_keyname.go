@@ -0,0 +1,37 @@
+//go:build !termui_pure && !windows
+
+package termui
+
+// #cgo !darwin,!openbsd pkg-config: ncurses
+// #cgo darwin openbsd LDFLAGS: -lncurses
+// #include <curses.h>
+import "C"
+
+var (
+	// Keys of the extended key names of ncurses. Ncurses gives these keys codes when it starts
+	ncExtendedKeys = map[string]Key{
+		"kUP5":  KeyCtrl | KeyUp,
+		"kDN5":  KeyCtrl | KeyDown,
+		"kLFT5": KeyCtrl | KeyLeft,
+		"kRIT5": KeyCtrl | KeyRight,
+		"kHOM5": KeyCtrl | KeyHome,
+		"kEND5": KeyCtrl | KeyEnd,
+		"kPRV5": KeyCtrl | KeyPageUp,
+		"kNXT5": KeyCtrl | KeyPageDown,
+	}
+)
+
+// Returns the key of the key code ncurses gave to an extended key. Other keys are returned as they are
+func ncExtendedKey(key Key) Key {
+	if key <= C.KEY_MAX {
+		return key
+	}
+	name := C.keyname(C.int(key))
+	if name == nil {
+		return key
+	}
+	if result, has := ncExtendedKeys[C.GoString(name)]; has {
+		return result
+	}
+	return key
+}
@@ -0,0 +1,23 @@
+//go:build !termui_pure && !windows
+
+package termui
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Notifies the screen about the resizes of the terminal
+func (s *NcursesScreen) watchResize() {
+	s.winch = make(chan os.Signal, 1)
+	signal.Notify(s.winch, syscall.SIGWINCH)
+}
+
+// Makes GetKey return KeyResize, as if the terminal was resized
+func (s *NcursesScreen) notifyResize() {
+	select {
+	case s.winch <- syscall.SIGWINCH:
+	default:
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !termui_pure
+
+package termui
+
+// The console of windows reports the resizes itself
+func (s *NcursesScreen) watchResize() {
+}
+
+// Processes aren't stopped on windows, so the size can't change unnoticed
+func (s *NcursesScreen) notifyResize() {
+}
+
+// PDCurses gives the extended keys their own codes
+func ncExtendedKey(key Key) Key {
+	return key
+}
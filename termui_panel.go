@@ -0,0 +1,195 @@
+package termui
+
+// A container that clips its children to the area
+type clippingContainer interface {
+	// Returns the area where the child is visible
+	clipArea(child *UIElementData) (y, x, height, width int)
+}
+
+// A container where the children have their own locations relative to the container
+type childPlacer interface {
+	// Sets the location of the child relative to the container
+	placeChild(data *UIElementData, y, x int)
+}
+
+// Returns the surface where the element is drawn. Clips the element to the areas of its containers
+func clippedSurface(s Surface, element hasElementData) Surface {
+	child := element.GetElementData()
+	for c := child.container; c != nil; child, c = c.GetElementData(), c.GetElementData().container {
+		if clipper, ok := c.(clippingContainer); ok {
+			y, x, height, width := clipper.clipArea(child)
+			s = clipSurface{parent: s, y: y, x: x, height: height, width: width}
+		}
+	}
+	return s
+}
+
+// Returns true if the point isn't clipped by the containers of the element
+func inClipArea(element hasElementData, y, x int) bool {
+	child := element.GetElementData()
+	for c := child.container; c != nil; child, c = c.GetElementData(), c.GetElementData().container {
+		if clipper, ok := c.(clippingContainer); ok {
+			cy, cx, height, width := clipper.clipArea(child)
+			if y < cy || x < cx || y >= cy+height || x >= cx+width {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// A child of the container and its location relative to the container
+type panelChild struct {
+	element UIElement
+	y, x    int
+}
+
+// The children of a container that places them relative to itself
+type relativeChildren []panelChild
+
+// Makes the container the parent of the elements. The locations the elements were created with become relative to the container
+func (c *relativeChildren) add(container UIElement, elements []UIElement) error {
+	for _, element := range elements {
+		err := adopt(container, element)
+		if err != nil {
+			return err
+		}
+		data := element.GetElementData()
+		*c = append(*c, panelChild{element: element, y: data.yPos - yOffset, x: data.xPos - xOffset})
+	}
+	return nil
+}
+
+// Sets the relative location of the child. Returns false if the element isn't one of the children
+func (c relativeChildren) place(data *UIElementData, y, x int) bool {
+	for i, child := range c {
+		if child.element.GetElementData() == data {
+			c[i].y = y
+			c[i].x = x
+			return true
+		}
+	}
+	return false
+}
+
+// Moves the children to their locations relative to the point
+func (c relativeChildren) layout(y, x int) {
+	for _, child := range c {
+		moveElement(child.element, y+child.y, x+child.x)
+	}
+}
+
+// Returns the elements of the children
+func (c relativeChildren) elements() []UIElement {
+	result := make([]UIElement, 0, len(c))
+	for _, child := range c {
+		result = append(result, child.element)
+	}
+	return result
+}
+
+// A container with a titled border. The locations of the children are relative to the inside of the border,
+// the parts of the children outside the panel aren't drawn
+type Panel struct {
+	data          *UIElementData
+	children      relativeChildren
+	height, width int
+	bcolor        string
+	cctTitle      *CCTMessage
+}
+
+// Creates a panel
+func NewPanel(menu Menu, y, x, height, width int, title string, borderColor string) (*Panel, error) {
+	result := Panel{}
+	result.data = createUIED(y, x)
+	result.data.Focusable = false
+	result.height = height
+	result.width = width
+	result.bcolor = borderColor
+	result.children = relativeChildren{}
+	_, err := ParseColorPair(borderColor)
+	if err != nil {
+		return nil, err
+	}
+	err = result.SetTitle(title)
+	if err != nil {
+		return nil, err
+	}
+	menu.AddElement(&result)
+	return &result, nil
+}
+
+// Sets the title of the panel
+func (p *Panel) SetTitle(title string) error {
+	var err error
+	p.cctTitle, err = ToCCTMessage(title)
+	return err
+}
+
+// Adds the elements to the panel. The locations the elements were created with become relative to the panel
+func (p *Panel) Add(elements ...UIElement) error {
+	err := p.children.add(p, elements)
+	if err != nil {
+		return err
+	}
+	p.Layout()
+	return nil
+}
+
+// Sets the location of the child relative to the panel
+func (p *Panel) placeChild(data *UIElementData, y, x int) {
+	p.children.place(data, y, x)
+	p.Layout()
+}
+
+// Returns the children of the panel
+func (p Panel) GetChildren() []UIElement {
+	return p.children.elements()
+}
+
+// Places the children inside the border
+func (p Panel) Layout() {
+	p.children.layout(p.data.yPos+1, p.data.xPos+1)
+}
+
+// Returns the inside of the border
+func (p Panel) clipArea(child *UIElementData) (int, int, int, int) {
+	return p.data.yPos + 1, p.data.xPos + 1, p.height - 2, p.width - 2
+}
+
+// Returns the element data of the panel
+func (p Panel) GetElementData() *UIElementData {
+	return p.data
+}
+
+// Clears the panel and draws the border with the title. The children are drawn by the menu
+func (p Panel) Draw(s Surface) error {
+	clearArea(s, p.data.yPos, p.data.xPos, p.height, p.width)
+	err := DrawBox(s, p.data.yPos, p.data.xPos, p.height, p.width, p.bcolor)
+	if err != nil {
+		return err
+	}
+	p.cctTitle.Draw(NewSubSurface(s, p.data.yPos, p.data.xPos+1, 1, p.width-2), 0, 0)
+	return nil
+}
+
+// Doesn't handle any keys
+func (p Panel) HandleKey(key Key) (bool, error) {
+	return false, nil
+}
+
+// Returns the height of the panel
+func (p Panel) Height() int {
+	return p.height
+}
+
+// Returns the width of the panel
+func (p Panel) Width() int {
+	return p.width
+}
+
+// Sets the height and width of the panel
+func (p *Panel) SetSize(height, width int) {
+	p.height = MaxInt(height, 2)
+	p.width = MaxInt(width, 2)
+}
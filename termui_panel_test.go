@@ -0,0 +1,65 @@
+package termui
+
+import "testing"
+
+func TestPanelGolden(t *testing.T) {
+	w, screen := newTestWindow(t, 10, 30)
+	menu := w.GetMenu()
+	// the children are created before the panel, the panel is still drawn below them
+	inside, err := NewLabel(menu, 0, 1, "${green}inside")
+	must(t, err)
+	clipped, err := NewLabel(menu, 2, 10, "clipped by the border")
+	must(t, err)
+	panel, err := NewPanel(menu, 1, 2, 5, 20, "${cyan}Panel", "red")
+	must(t, err)
+	must(t, panel.Add(inside, clipped))
+	expectYX(t, "inside", inside, 2, 4)
+	expectYX(t, "clipped", clipped, 4, 13)
+	matchGolden(t, w, screen, "panel")
+}
+
+func TestPanelRelativeLocations(t *testing.T) {
+	w, _ := newTestWindow(t, 10, 30)
+	menu := w.GetMenu()
+	panel, err := NewPanel(menu, 1, 2, 5, 20, "Panel", "normal")
+	must(t, err)
+	label, err := NewLabel(menu, 0, 0, "label")
+	must(t, err)
+	must(t, panel.Add(label))
+	SetYX(label, 1, 3)
+	expectYX(t, "label", label, 3, 6)
+	SetYX(panel, 4, 0)
+	must(t, menu.Draw())
+	expectYX(t, "label", label, 6, 4)
+	if panel.Add(label) == nil {
+		t.Fatal("added the child twice")
+	}
+	if len(panel.GetChildren()) != 1 {
+		t.Fatalf("panel has %v children, want 1", len(panel.GetChildren()))
+	}
+}
+
+func TestElementAtPrefersChildren(t *testing.T) {
+	w, _ := newTestWindow(t, 10, 30)
+	menu := w.GetMenu().(*NormalMenu)
+	button, err := NewButton(menu, 0, 0, "button", func() error {
+		return nil
+	}, KeyEnter)
+	must(t, err)
+	panel, err := NewPanel(menu, 0, 0, 5, 20, "Panel", "normal")
+	must(t, err)
+	must(t, panel.Add(button))
+	must(t, menu.Draw())
+	if got := menu.elementAt(2, 3); got != button {
+		t.Fatalf("got %T under the pointer, want the button", got)
+	}
+	if got := menu.elementAt(1, 1); got != panel {
+		t.Fatalf("got %T on the border, want the panel", got)
+	}
+	// the child outside the panel is clipped
+	SetYX(button, 0, 17)
+	must(t, menu.Draw())
+	if got := menu.elementAt(2, 21); got != nil {
+		t.Fatalf("got %T outside the panel", got)
+	}
+}
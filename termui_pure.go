@@ -0,0 +1,8 @@
+//go:build termui_pure && linux
+
+package termui
+
+// Creates the terminal screen
+func newDefaultScreen() (Screen, error) {
+	return NewTermScreen()
+}
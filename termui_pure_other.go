@@ -0,0 +1,10 @@
+//go:build termui_pure && !linux
+
+package termui
+
+import "fmt"
+
+// TermScreen only drives the terminals of linux
+func newDefaultScreen() (Screen, error) {
+	return nil, fmt.Errorf("termui - the termui_pure tag is only supported on linux")
+}
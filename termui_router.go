@@ -0,0 +1,209 @@
+package termui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The parameters of a route: the segments of the path matched by the :name segments of the pattern
+type RouteParams map[string]string
+
+// A registered route
+type route struct {
+	pattern  string
+	segments []string
+	create   func(params RouteParams) (Menu, error)
+}
+
+// A menu opened by the router and its path
+type routedMenu struct {
+	menu Menu
+	path string
+}
+
+// Opens the menus by their paths. The routes are registered with patterns like "user/:id",
+// where the :id segment matches any segment of the path and is passed to the handler as a parameter.
+// The menus are pushed to the history of the window, so PopMenu and the back key of the window go back to the previous route
+type Router struct {
+	window *Window
+	routes []route
+	opened []routedMenu
+}
+
+// Creates a router of the window
+func NewRouter(window *Window) *Router {
+	result := Router{}
+	result.window = window
+	result.routes = []route{}
+	result.opened = []routedMenu{}
+	return &result
+}
+
+// Splits the path into segments. The slashes at the ends of the path are ignored
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return []string{}
+	}
+	return strings.Split(path, "/")
+}
+
+// Registers the route. The handler creates the menu of the route from the parameters.
+// If several routes match a path, the route registered first is used
+func (r *Router) Handle(pattern string, handler func(params RouteParams) (Menu, error)) error {
+	segments := splitPath(pattern)
+	names := map[string]bool{}
+	for _, segment := range segments {
+		if segment == "" {
+			return fmt.Errorf("termui - route %s has an empty segment", pattern)
+		}
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+		name := segment[1:]
+		if name == "" || names[name] {
+			return fmt.Errorf("termui - route %s has an invalid parameter %s", pattern, segment)
+		}
+		names[name] = true
+	}
+	for _, rt := range r.routes {
+		if routeShape(rt.segments) == routeShape(segments) {
+			return fmt.Errorf("termui - route %s matches the same paths as route %s", pattern, rt.pattern)
+		}
+	}
+	r.routes = append(r.routes, route{pattern: pattern, segments: segments, create: handler})
+	return nil
+}
+
+// Returns the pattern without the names of the parameters, the patterns with the same shape match the same paths
+func routeShape(segments []string) string {
+	shape := make([]string, len(segments))
+	for i, segment := range segments {
+		shape[i] = segment
+		if strings.HasPrefix(segment, ":") {
+			shape[i] = ":"
+		}
+	}
+	return strings.Join(shape, "/")
+}
+
+// Returns the parameters of the path if it matches the route
+func (rt route) match(segments []string) (RouteParams, bool) {
+	if len(segments) != len(rt.segments) {
+		return nil, false
+	}
+	params := RouteParams{}
+	for i, segment := range rt.segments {
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			params[segment[1:]] = segments[i]
+		case segment != segments[i]:
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// Creates the menu of the path. Returns the menu and the normalized path
+func (r *Router) createMenu(path string) (Menu, string, error) {
+	segments := splitPath(path)
+	for _, rt := range r.routes {
+		params, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		menu, err := rt.create(params)
+		if err != nil {
+			return nil, "", err
+		}
+		if menu == nil {
+			return nil, "", fmt.Errorf("termui - route %s created no menu for %s", rt.pattern, path)
+		}
+		return menu, strings.Join(segments, "/"), nil
+	}
+	return nil, "", fmt.Errorf("termui - no route matches %s", path)
+}
+
+// Creates the menu of the path and opens it with open. The path is remembered before the menu is opened,
+// so the hooks of the menu see it, and forgotten if the menu isn't in the window afterwards
+func (r *Router) openPath(path string, open func(menu Menu) error) error {
+	menu, normalized, err := r.createMenu(path)
+	if err != nil {
+		return err
+	}
+	r.opened = append(r.opened, routedMenu{menu: menu, path: normalized})
+	err = open(menu)
+	r.forgetClosed()
+	return err
+}
+
+// Forgets the paths of the menus that aren't in the window anymore
+func (r *Router) forgetClosed() {
+	menus := append(r.window.GetHistory(), r.window.GetMenu())
+	opened := []routedMenu{}
+	for _, o := range r.opened {
+		for _, menu := range menus {
+			if o.menu == menu {
+				opened = append(opened, o)
+				break
+			}
+		}
+	}
+	r.opened = opened
+}
+
+// Opens the menu of the path above the current menu
+func (r *Router) Navigate(path string) error {
+	return r.openPath(path, r.window.PushMenu)
+}
+
+// Opens the menu of the path in place of the current menu
+func (r *Router) Replace(path string) error {
+	return r.openPath(path, r.window.ReplaceMenu)
+}
+
+// Opens the menu of the path as the only menu of the window, the history is cleared
+func (r *Router) Open(path string) error {
+	return r.openPath(path, r.window.ResetMenu)
+}
+
+// Goes back to the previous menu
+func (r *Router) Back() error {
+	return r.window.PopMenu()
+}
+
+// Opens the menu of the path and starts the window. Used to start the app on a route passed on the command line
+func (r *Router) Start(path string) error {
+	err := r.Open(path)
+	if err != nil {
+		return err
+	}
+	return r.window.Start()
+}
+
+// Returns the path of the menu, or false if the menu wasn't opened by the router
+func (r *Router) PathOf(menu Menu) (string, bool) {
+	for _, o := range r.opened {
+		if o.menu == menu {
+			return o.path, true
+		}
+	}
+	return "", false
+}
+
+// Returns the path of the current menu, or false if the current menu wasn't opened by the router
+func (r *Router) Current() (string, bool) {
+	return r.PathOf(r.window.GetMenu())
+}
+
+// Returns the paths of the menus in the history of the window, from the first to the current one.
+// The menus that weren't opened by the router are skipped
+func (r *Router) Breadcrumbs() []string {
+	result := []string{}
+	for _, menu := range append(r.window.GetHistory(), r.window.GetMenu()) {
+		if path, ok := r.PathOf(menu); ok {
+			result = append(result, path)
+		}
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+package termui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Returns the title of the current menu
+func menuTitle(w *Window) string {
+	return w.GetMenu().(*NormalMenu).cctTitle.ToRawString()
+}
+
+func TestRouter(t *testing.T) {
+	w, _ := newTestWindow(t, 5, 20)
+	router := NewRouter(w)
+	// the menus are titled with their routes
+	must(t, router.Handle("home", func(params RouteParams) (Menu, error) {
+		return NewNormalMenu("home")
+	}))
+	must(t, router.Handle("user/new", func(params RouteParams) (Menu, error) {
+		return NewNormalMenu("new user")
+	}))
+	must(t, router.Handle("/user/:id/", func(params RouteParams) (Menu, error) {
+		if params["id"] == "broken" {
+			return nil, fmt.Errorf("no user %v", params["id"])
+		}
+		return NewNormalMenu("user " + params["id"])
+	}))
+	create := func(params RouteParams) (Menu, error) {
+		return NewNormalMenu("")
+	}
+	for _, pattern := range []string{
+		"a//b",
+		"user/:",
+		"a/:x/:x",
+		"home",
+		"/home/",
+		"user/:name",
+	} {
+		if router.Handle(pattern, create) == nil {
+			t.Errorf("%q: expected an error", pattern)
+		}
+	}
+	must(t, router.Handle("user/:id/posts", create))
+
+	must(t, router.Open("home"))
+	must(t, router.Navigate("/user/42"))
+	must(t, router.Navigate("user/new"))
+	if got := menuTitle(w); got != "new user" {
+		t.Fatalf("the route registered first wasn't used, got menu %q", got)
+	}
+	must(t, router.Replace("user/7"))
+	if got := strings.Join(router.Breadcrumbs(), " "); got != "home user/42 user/7" {
+		t.Fatalf("got breadcrumbs %v", got)
+	}
+	must(t, router.Back())
+	if path, ok := router.Current(); !ok || path != "user/42" {
+		t.Fatalf("current path is %v, %v", path, ok)
+	}
+	if router.Navigate("nowhere") == nil {
+		t.Fatal("navigated to the path without a route")
+	}
+	must(t, router.Open("user/1"))
+	if w.CanGoBack() || strings.Join(router.Breadcrumbs(), " ") != "user/1" {
+		t.Fatalf("open kept the history, breadcrumbs %v", router.Breadcrumbs())
+	}
+
+	must(t, router.Open("home"))
+	if router.Navigate("user/broken") == nil {
+		t.Fatal("expected the error of the handler")
+	}
+	w.running = true
+	// the path is known when the menu is entered
+	breadcrumbs := ""
+	must(t, router.Handle("settings", func(params RouteParams) (Menu, error) {
+		menu, err := NewNormalMenu("settings")
+		if err != nil {
+			return nil, err
+		}
+		menu.SetOnEnter(func() error {
+			breadcrumbs = strings.Join(router.Breadcrumbs(), " ")
+			return nil
+		})
+		return menu, nil
+	}))
+	must(t, router.Navigate("settings"))
+	if breadcrumbs != "home settings" {
+		t.Fatalf("got breadcrumbs %q in OnEnter", breadcrumbs)
+	}
+	must(t, router.Back())
+	// the menu whose OnEnter fails isn't remembered, the window goes back to the previous menu
+	must(t, router.Handle("failing", func(params RouteParams) (Menu, error) {
+		menu, err := NewNormalMenu("failing")
+		if err != nil {
+			return nil, err
+		}
+		menu.SetOnEnter(func() error {
+			return fmt.Errorf("can't enter")
+		})
+		return menu, nil
+	}))
+	if router.Navigate("failing") == nil {
+		t.Fatal("expected the error of OnEnter")
+	}
+	if path, _ := router.Current(); path != "home" || menuTitle(w) != "home" {
+		t.Fatalf("the window didn't go back to home, current path %q", path)
+	}
+	if w.CanGoBack() {
+		t.Fatalf("the history changed to %v", w.GetHistory())
+	}
+	if len(router.opened) != 1 {
+		t.Fatalf("the router remembers %v menus, want 1", len(router.opened))
+	}
+	if got := strings.Join(router.Breadcrumbs(), " "); got != "home" {
+		t.Fatalf("got breadcrumbs %v", got)
+	}
+}
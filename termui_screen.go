@@ -0,0 +1,173 @@
+package termui
+
+// A key read from the screen
+//
+// Special key values match the curses key codes
+type Key int
+
+// Attributes of a cell. The lower bits hold the color pair, the upper bits hold the text attributes
+type Attr uint32
+
+const (
+	attrPairMask Attr = 0xffff
+
+	AttrNormal    Attr = 0
+	AttrReverse   Attr = 1 << 16
+	AttrBold      Attr = 1 << 17
+	AttrUnderline Attr = 1 << 18
+	AttrDim       Attr = 1 << 19
+)
+
+// Box drawing characters. Backends are expected to map them to the line drawing characters of the terminal
+const (
+	runeULCorner = '┌'
+	runeURCorner = '┐'
+	runeLLCorner = '└'
+	runeLRCorner = '┘'
+	runeHLine    = '─'
+	runeVLine    = '│'
+	runeLTee     = '├'
+	runeRTee     = '┤'
+	runeUArrow   = '↑'
+	runeDArrow   = '↓'
+	runeLArrow   = '←'
+	runeRArrow   = '→'
+	runeBlock    = '█'
+)
+
+// Returns the color pair of the attribute
+func (a Attr) Pair() int16 {
+	return int16(a & attrPairMask)
+}
+
+// Returns the text attributes without the color pair
+func (a Attr) Flags() Attr {
+	return a &^ attrPairMask
+}
+
+// Creates the attribute of the color pair
+func colorPairAttr(pair int16) Attr {
+	return Attr(pair) & attrPairMask
+}
+
+// Joins the attributes. The last color pair wins, the text attributes are combined
+func joinAttrs(attrs ...Attr) Attr {
+	result := AttrNormal
+	for _, attr := range attrs {
+		if attr.Pair() != 0 {
+			result = result.Flags() | colorPairAttr(attr.Pair())
+		}
+		result |= attr.Flags()
+	}
+	return result
+}
+
+// A drawing surface. Elements, templates and CCT messages draw against it
+type Surface interface {
+	// Returns the height and width of the surface
+	MaxYX() (int, int)
+	// Puts the character with the attribute at the location
+	SetCell(y, x int, ch rune, attr Attr)
+}
+
+// A terminal screen. Every backend implements this interface
+type Screen interface {
+	Surface
+
+	// Clears the screen
+	Erase()
+	// Flushes everything that was drawn to the terminal
+	Refresh()
+	// Moves the cursor to the location
+	MoveCursor(y, x int)
+	// Shows or hides the cursor
+	SetCursorVisible(visible bool)
+	// Blocks until a key is pressed and returns it
+	GetKey() Key
+	// Initializes the color pair with the foreground and background colors (-1 is the default color)
+	InitPair(pair, fg, bg int16) error
+	// Beeps
+	Beep()
+	// Flashes the screen
+	Flash()
+	// Restores the terminal
+	End()
+}
+
+// A rectangular part of another surface
+type subSurface struct {
+	parent        Surface
+	y, x          int
+	height, width int
+}
+
+// Creates a surface that draws to the area of the parent surface
+func NewSubSurface(parent Surface, y, x, height, width int) Surface {
+	result := subSurface{}
+	result.parent = parent
+	result.y = y
+	result.x = x
+	result.height = height
+	result.width = width
+	return &result
+}
+
+// Returns the height and width of the sub surface
+func (s subSurface) MaxYX() (int, int) {
+	return s.height, s.width
+}
+
+// Puts the character to the parent surface. Characters outside the sub surface are ignored
+func (s subSurface) SetCell(y, x int, ch rune, attr Attr) {
+	if y < 0 || x < 0 || y >= s.height || x >= s.width {
+		return
+	}
+	s.parent.SetCell(y+s.y, x+s.x, ch, attr)
+}
+
+// A surface that ignores the characters outside of the area of the parent surface.
+// Unlike the sub surface, the locations are the same as on the parent
+type clipSurface struct {
+	parent        Surface
+	y, x          int
+	height, width int
+}
+
+// Returns the height and width of the parent surface
+func (s clipSurface) MaxYX() (int, int) {
+	return s.parent.MaxYX()
+}
+
+// Puts the character to the parent surface if it's inside the area
+func (s clipSurface) SetCell(y, x int, ch rune, attr Attr) {
+	if y < s.y || x < s.x || y >= s.y+s.height || x >= s.x+s.width {
+		return
+	}
+	s.parent.SetCell(y, x, ch, attr)
+}
+
+// A surface that adds the dim attribute to everything drawn on it
+type dimSurface struct {
+	parent Surface
+}
+
+// Returns the height and width of the parent surface
+func (s dimSurface) MaxYX() (int, int) {
+	return s.parent.MaxYX()
+}
+
+// Puts the dimmed character to the parent surface
+func (s dimSurface) SetCell(y, x int, ch rune, attr Attr) {
+	s.parent.SetCell(y, x, ch, attr|AttrDim)
+}
+
+// Returns the surface where disabled elements are drawn
+func elementSurface(s Surface, data *UIElementData) Surface {
+	if data.Enabled {
+		return s
+	}
+	return dimSurface{parent: s}
+}
+
+// The screen of the last created window. Color pairs are initialized on it
+var activeScreen Screen
@@ -0,0 +1,272 @@
+package termui
+
+// A container that shows a part of its children. The children are located in the virtual space of the scroll view,
+// the scroll view shows the part of the space that starts at the scroll location.
+// The scroll bars are drawn on the right column and, if the children are wider than the scroll view, on the bottom row.
+// The focused child is scrolled into view. PgUp and PgDn scroll by a page, the mouse wheel scrolls by a row
+type ScrollView struct {
+	data             *UIElementData
+	menu             Menu
+	children         relativeChildren
+	height, width    int
+	scrollY, scrollX int
+	bcolor           string
+	// The focused element when the children were placed the last time
+	lastFocused   UIElement
+	ScrollUpKey   Key
+	ScrollDownKey Key
+}
+
+// Creates a scroll view. The scroll bars are drawn with the scrollbar color
+func NewScrollView(menu Menu, y, x, height, width int, scrollbarColor string) (*ScrollView, error) {
+	result := ScrollView{}
+	result.data = createUIED(y, x)
+	result.data.Focusable = false
+	result.menu = menu
+	result.children = relativeChildren{}
+	result.height = height
+	result.width = width
+	result.bcolor = scrollbarColor
+	result.ScrollUpKey = KeyPageUp
+	result.ScrollDownKey = KeyPageDown
+	_, err := ParseColorPair(scrollbarColor)
+	if err != nil {
+		return nil, err
+	}
+	menu.AddElement(&result)
+	return &result, nil
+}
+
+// Adds the elements to the scroll view. The locations the elements were created with become the locations in the virtual space
+func (v *ScrollView) Add(elements ...UIElement) error {
+	err := v.children.add(v, elements)
+	if err != nil {
+		return err
+	}
+	v.Layout()
+	return nil
+}
+
+// Sets the location of the child in the virtual space
+func (v *ScrollView) placeChild(data *UIElementData, y, x int) {
+	v.children.place(data, y, x)
+	v.Layout()
+}
+
+// Returns the children of the scroll view
+func (v ScrollView) GetChildren() []UIElement {
+	return v.children.elements()
+}
+
+// Returns the height and width of the virtual space taken by the visible children
+func (v ScrollView) contentSize() (int, int) {
+	height, width := 0, 0
+	for _, child := range v.children {
+		if !child.element.GetElementData().Visible {
+			continue
+		}
+		height = MaxInt(height, child.y+child.element.Height())
+		width = MaxInt(width, child.x+child.element.Width())
+	}
+	return height, width
+}
+
+// Returns true if the children are wider than the scroll view, then the bottom row has the scroll bar
+func (v ScrollView) hasHScrollbar() bool {
+	_, width := v.contentSize()
+	return width > v.width-1
+}
+
+// Returns the size of the shown part of the virtual space
+func (v ScrollView) viewportSize() (int, int) {
+	height := v.height
+	if v.hasHScrollbar() {
+		height--
+	}
+	return MaxInt(height, 0), MaxInt(v.width-1, 0)
+}
+
+// Returns the scroll location: the row and the column of the virtual space shown in the top left corner
+func (v ScrollView) GetScroll() (int, int) {
+	return v.scrollY, v.scrollX
+}
+
+// Scrolls to the location of the virtual space. The location is kept inside the virtual space
+func (v *ScrollView) ScrollTo(y, x int) {
+	contentHeight, contentWidth := v.contentSize()
+	viewHeight, viewWidth := v.viewportSize()
+	v.scrollY = MaxInt(MinInt(y, contentHeight-viewHeight), 0)
+	v.scrollX = MaxInt(MinInt(x, contentWidth-viewWidth), 0)
+	v.place()
+}
+
+// Scrolls by the amount of rows and columns
+func (v *ScrollView) ScrollBy(dy, dx int) {
+	v.ScrollTo(v.scrollY+dy, v.scrollX+dx)
+}
+
+// Places the children at their virtual locations, moved by the scroll location
+func (v ScrollView) place() {
+	v.children.layout(v.data.yPos-v.scrollY, v.data.xPos-v.scrollX)
+}
+
+// Places the children. If another child got the focus, scrolls it into view
+func (v *ScrollView) Layout() {
+	v.ScrollTo(v.scrollY, v.scrollX)
+	focused := focusedElement(v.menu)
+	if focused == v.lastFocused {
+		return
+	}
+	v.lastFocused = focused
+	if focused != nil && inContainer(focused, v) {
+		v.scrollIntoView(focused)
+	}
+}
+
+// Scrolls the least amount that shows the element. The top left corner of the elements larger than the scroll view is shown
+func (v *ScrollView) scrollIntoView(element UIElement) {
+	data := element.GetElementData()
+	viewHeight, viewWidth := v.viewportSize()
+	// the location of the element in the virtual space
+	y := data.yPos - v.data.yPos + v.scrollY
+	x := data.xPos - v.data.xPos + v.scrollX
+	scrollY, scrollX := v.scrollY, v.scrollX
+	if y+element.Height() > scrollY+viewHeight {
+		scrollY = y + element.Height() - viewHeight
+	}
+	if y < scrollY {
+		scrollY = y
+	}
+	if x+element.Width() > scrollX+viewWidth {
+		scrollX = x + element.Width() - viewWidth
+	}
+	if x < scrollX {
+		scrollX = x
+	}
+	v.ScrollTo(scrollY, scrollX)
+}
+
+// Returns the shown part of the virtual space
+func (v ScrollView) clipArea(child *UIElementData) (int, int, int, int) {
+	height, width := v.viewportSize()
+	return v.data.yPos, v.data.xPos, height, width
+}
+
+// Returns the element data of the scroll view
+func (v ScrollView) GetElementData() *UIElementData {
+	return v.data
+}
+
+// Draws the scroll bar. The bar is length cells long, the arrows are on its ends.
+// Offset is the first shown of the total cells, shown is the amount of the shown cells
+func drawScrollbar(s Surface, y, x, length, offset, shown, total int, vertical bool, colorPair string) error {
+	color, err := ParseColorPair(colorPair)
+	if err != nil {
+		return err
+	}
+	thumbColor, err := ParseColorPair(ReverseColorPair(colorPair))
+	if err != nil {
+		return err
+	}
+	set := func(i int, ch rune, attr Attr) {
+		if vertical {
+			s.SetCell(y+i, x, ch, attr)
+		} else {
+			s.SetCell(y, x+i, ch, attr)
+		}
+	}
+	first, last, line := runeUArrow, runeDArrow, runeVLine
+	if !vertical {
+		first, last, line = runeLArrow, runeRArrow, runeHLine
+	}
+	// draw the arrows
+	if offset != 0 {
+		set(0, first, color)
+	}
+	if offset+shown < total {
+		set(length-1, last, color)
+	}
+	// draw the line
+	lineLength := length - 2
+	for i := 0; i < lineLength; i++ {
+		set(1+i, line, color)
+	}
+	// draw the thumb
+	thumbLength := MinInt(shown*lineLength/total+1, lineLength)
+	thumbOffset := MinInt(offset*lineLength/total, lineLength-thumbLength)
+	for i := 0; i < thumbLength; i++ {
+		set(1+thumbOffset+i, ' ', thumbColor)
+	}
+	return nil
+}
+
+// Clears the scroll view and draws the scroll bars. The children are drawn by the menu
+func (v ScrollView) Draw(s Surface) error {
+	clearArea(s, v.data.yPos, v.data.xPos, v.height, v.width)
+	contentHeight, contentWidth := v.contentSize()
+	viewHeight, viewWidth := v.viewportSize()
+	if contentHeight > viewHeight {
+		err := drawScrollbar(s, v.data.yPos, v.data.xPos+viewWidth, viewHeight, v.scrollY, viewHeight, contentHeight, true, v.bcolor)
+		if err != nil {
+			return err
+		}
+	}
+	if contentWidth > viewWidth {
+		err := drawScrollbar(s, v.data.yPos+viewHeight, v.data.xPos, viewWidth, v.scrollX, viewWidth, contentWidth, false, v.bcolor)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// On the scroll keys scrolls by a page. The keys come from the focused children
+func (v *ScrollView) HandleKey(key Key) (bool, error) {
+	viewHeight, _ := v.viewportSize()
+	switch key {
+	case v.ScrollUpKey:
+		v.ScrollBy(-viewHeight, 0)
+	case v.ScrollDownKey:
+		v.ScrollBy(viewHeight, 0)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// On mouse wheel scrolls by a row. A click on the arrows of the scroll bars scrolls by a row or a column
+func (v *ScrollView) HandleMouse(event MouseEvent) error {
+	viewHeight, viewWidth := v.viewportSize()
+	switch {
+	case event.Button == MouseWheelUp:
+		v.ScrollBy(-1, 0)
+	case event.Button == MouseWheelDown:
+		v.ScrollBy(1, 0)
+	case !event.IsClick():
+	case event.X == viewWidth && event.Y == 0:
+		v.ScrollBy(-1, 0)
+	case event.X == viewWidth && event.Y == viewHeight-1:
+		v.ScrollBy(1, 0)
+	case event.Y == viewHeight && event.X == 0:
+		v.ScrollBy(0, -1)
+	case event.Y == viewHeight && event.X == viewWidth-1:
+		v.ScrollBy(0, 1)
+	}
+	return nil
+}
+
+// Returns the height of the scroll view
+func (v ScrollView) Height() int {
+	return v.height
+}
+
+// Returns the width of the scroll view
+func (v ScrollView) Width() int {
+	return v.width
+}
+
+// Sets the height and width of the scroll view
+func (v *ScrollView) SetSize(height, width int) {
+	v.height = MaxInt(height, 1)
+	v.width = MaxInt(width, 2)
+}
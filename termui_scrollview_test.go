@@ -0,0 +1,46 @@
+package termui
+
+import "testing"
+
+func TestScrollView(t *testing.T) {
+	w, screen := newTestWindow(t, 7, 20)
+	menu := w.GetMenu()
+	view, err := NewScrollView(menu, 0, 0, 4, 12, "red")
+	must(t, err)
+	buttons := []*Button{}
+	for i := 0; i < 8; i++ {
+		button, err := NewButton(menu, i, 0, "button "+string(rune('a'+i)), func() error {
+			return nil
+		}, KeyEnter)
+		must(t, err)
+		must(t, view.Add(button))
+		buttons = append(buttons, button)
+	}
+	matchGolden(t, w, screen, "scrollview")
+	view.ScrollTo(3, 0)
+	matchGolden(t, w, screen, "scrollview_scrolled")
+	// the scroll location is kept inside the virtual space
+	view.ScrollTo(100, 100)
+	if y, x := view.GetScroll(); y != 4 || x != 0 {
+		t.Fatalf("scrolled to %v:%v, want 4:0", y, x)
+	}
+	view.ScrollBy(-10, 0)
+	if y, _ := view.GetScroll(); y != 0 {
+		t.Fatalf("scrolled to row %v, want 0", y)
+	}
+	must(t, view.HandleMouse(MouseEvent{Button: MouseWheelDown, Action: MousePress}))
+	if y, _ := view.GetScroll(); y != 1 {
+		t.Fatalf("scrolled to row %v, want 1", y)
+	}
+	expectYX(t, "second button", buttons[1], 0, 0)
+	// the focused child is scrolled into view
+	menu.Focus(buttons[6])
+	must(t, menu.Draw())
+	if y, _ := view.GetScroll(); y != 3 {
+		t.Fatalf("scrolled to row %v, want 3", y)
+	}
+	must(t, w.handleKey(view.ScrollUpKey))
+	if y, _ := view.GetScroll(); y != 0 {
+		t.Fatalf("scrolled to row %v, want 0", y)
+	}
+}
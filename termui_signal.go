@@ -0,0 +1,65 @@
+package termui
+
+import (
+	"os"
+	"os/signal"
+)
+
+const (
+	// Ctrl+C, the terminal sends SIGINT for it only outside the raw mode
+	keyInterrupt Key = 3
+	// Ctrl+Z, the terminal sends SIGTSTP for it only outside the raw mode
+	keySuspend Key = 26
+)
+
+// Implemented by the screens that can give the terminal back to the shell
+type Suspender interface {
+	// Restores the modes of the terminal
+	Suspend()
+	// Puts the terminal back into the modes of the screen and redraws it
+	Resume()
+}
+
+// Handles the signals of the process on the window goroutine. Returns the function that stops watching them
+func (w *Window) watchSignals() func() {
+	if _, ok := w.screen.(Suspender); !ok {
+		// only the terminal screens handle the signals
+		return func() {}
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, watchedSignals...)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				w.QueueUpdate(func() {
+					w.handleSignal(sig)
+				})
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// The terminal screens read ctrl+C and ctrl+Z as keys, so they are handled like SIGINT and SIGTSTP
+// when nothing else handles them. Returns false for the other keys
+func (w *Window) handleSignalKey(key Key) bool {
+	if _, ok := w.screen.(Suspender); !ok {
+		return false
+	}
+	switch key {
+	case keyInterrupt:
+		w.RequestExit()
+	case keySuspend:
+		w.Suspend()
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package termui
+
+import "testing"
+
+// Virtual screen that can be suspended, like the terminal screens
+type suspendableScreen struct {
+	*VirtualScreen
+	suspended      bool
+	resumes        int
+	drawnSuspended bool
+}
+
+func (s *suspendableScreen) Suspend() {
+	s.suspended = true
+}
+
+func (s *suspendableScreen) Resume() {
+	s.suspended = false
+	s.resumes++
+}
+
+func (s *suspendableScreen) Refresh() {
+	if s.suspended {
+		s.drawnSuspended = true
+	}
+	s.VirtualScreen.Refresh()
+}
+
+func TestCtrlCRequestsExit(t *testing.T) {
+	screen := &suspendableScreen{VirtualScreen: NewVirtualScreen(5, 20)}
+	w, err := CreateWindowWithScreen(screen, "Test")
+	must(t, err)
+	requests := 0
+	w.BeforeExit(func() bool {
+		requests++
+		return requests > 1
+	})
+	// the first request is vetoed
+	input := NewKeysInput(keyInterrupt, keyInterrupt, 'a')
+	w.SetInput(input)
+	must(t, w.Start())
+	if requests != 2 || input.Remaining() != 1 {
+		t.Fatalf("ctrl+C requested the exit %d times, want 2", requests)
+	}
+}
+
+func TestCtrlCBinding(t *testing.T) {
+	screen := &suspendableScreen{VirtualScreen: NewVirtualScreen(5, 20)}
+	w, err := CreateWindowWithScreen(screen, "Test")
+	must(t, err)
+	// bindings come before the signal keys
+	copied := false
+	must(t, w.Bind("ctrl+c", func() error {
+		copied = true
+		return nil
+	}))
+	input := NewKeysInput(keyInterrupt, 'a')
+	w.SetInput(input)
+	must(t, w.Start())
+	if !copied || input.Remaining() != 0 {
+		t.Fatal("the binding of ctrl+C wasn't called instead of the exit")
+	}
+}
+
+func TestSignalKeysNeedSuspender(t *testing.T) {
+	w, _ := newTestWindow(t, 5, 20)
+	input := NewKeysInput(keyInterrupt, 'a')
+	w.SetInput(input)
+	must(t, w.Start())
+	if input.Remaining() != 0 {
+		t.Fatal("ctrl+C exited the window of the virtual screen")
+	}
+}
@@ -0,0 +1,57 @@
+//go:build !windows
+
+package termui
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+var (
+	// Signals the window handles while it is running
+	watchedSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGTSTP, syscall.SIGCONT}
+)
+
+// Requests the exit on SIGINT, exits on SIGTERM, suspends on SIGTSTP, redraws on SIGCONT
+func (w *Window) handleSignal(sig os.Signal) {
+	switch sig {
+	case syscall.SIGINT:
+		w.RequestExit()
+	case syscall.SIGTSTP:
+		w.Suspend()
+	case syscall.SIGCONT:
+		// Suspend resumes the screen itself. After the process was stopped from outside the screen is resumed here,
+		// as the shell could have changed the modes of the terminal
+		if w.resumed {
+			w.resumed = false
+			return
+		}
+		if s, ok := w.screen.(Suspender); ok {
+			s.Resume()
+		}
+	default:
+		w.Exit()
+	}
+}
+
+// Gives the terminal back to the shell and stops the process.
+// Resumes the screen after the process is continued
+func (w *Window) Suspend() {
+	s, ok := w.screen.(Suspender)
+	if !ok {
+		return
+	}
+	s.Suspend()
+	// kill can return before the process is stopped, so SIGCONT is waited for
+	cont := make(chan os.Signal, 1)
+	signal.Notify(cont, syscall.SIGCONT)
+	defer signal.Stop(cont)
+	err := syscall.Kill(syscall.Getpid(), syscall.SIGSTOP)
+	if err == nil {
+		<-cont
+		// the running window gets the same SIGCONT, which doesn't resume the screen again
+		w.resumed = w.running
+	}
+	s.Resume()
+}
@@ -0,0 +1,60 @@
+//go:build !windows
+
+package termui
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestSuspendResumesOnce(t *testing.T) {
+	screen := &suspendableScreen{VirtualScreen: NewVirtualScreen(5, 20)}
+	w, err := CreateWindowWithScreen(screen, "Test")
+	must(t, err)
+	input := make(chanInput)
+	w.SetInput(input)
+	done := make(chan error)
+	go func() {
+		done <- w.Start()
+	}()
+	// waits until the window goroutine sees the condition
+	waitFor := func(what string, cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			result := make(chan bool)
+			w.QueueUpdate(func() {
+				result <- cond()
+			})
+			if <-result {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %v", what)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	// the process stops itself, so another process continues it
+	cont := exec.Command("sh", "-c", "sleep 0.2; kill -CONT "+strconv.Itoa(os.Getpid()))
+	must(t, cont.Start())
+	input <- keySuspend
+	must(t, cont.Wait())
+	waitFor("the SIGCONT of the suspend", func() bool {
+		return screen.resumes == 1 && !w.resumed
+	})
+	// the process continued from outside resumes the screen again
+	must(t, syscall.Kill(os.Getpid(), syscall.SIGCONT))
+	waitFor("the SIGCONT from outside", func() bool {
+		return screen.resumes == 2
+	})
+	close(input)
+	must(t, <-done)
+	if screen.drawnSuspended {
+		t.Fatal("the window was drawn while the screen was suspended")
+	}
+}
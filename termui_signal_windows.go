@@ -0,0 +1,26 @@
+//go:build windows
+
+package termui
+
+import (
+	"os"
+	"syscall"
+)
+
+var (
+	// Signals the window handles while it is running
+	watchedSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+)
+
+// Requests the exit on interrupt, exits on the other signals
+func (w *Window) handleSignal(sig os.Signal) {
+	if sig == os.Interrupt {
+		w.RequestExit()
+		return
+	}
+	w.Exit()
+}
+
+// Processes can't be stopped on windows, does nothing
+func (w *Window) Suspend() {
+}
@@ -0,0 +1,217 @@
+package termui
+
+// The direction the split divides its area in
+type SplitDirection int
+
+const (
+	// The panes are side by side, the divider is a column
+	SplitLeftRight SplitDirection = iota
+	// The panes are one above the other, the divider is a row
+	SplitTopBottom
+)
+
+// A container that divides its area between two panes. The panes are usually other containers.
+// The panes are placed and, if they are Sizable, resized to fill their part of the area.
+// The divider is moved with ShrinkKey and GrowKey (ctrl+left/right or ctrl+up/down) and by dragging it with the mouse
+type Split struct {
+	data          *UIElementData
+	direction     SplitDirection
+	height, width int
+	// The size of the first pane
+	position      int
+	first, second UIElement
+	bcolor        string
+	dragging      bool
+	// The minimum sizes of the panes
+	MinFirst, MinSecond int
+	ShrinkKey           Key
+	GrowKey             Key
+}
+
+// Creates a split. Position is the size of the first pane
+func NewSplit(menu Menu, y, x, height, width int, direction SplitDirection, position int, borderColor string) (*Split, error) {
+	result := Split{}
+	result.data = createUIED(y, x)
+	result.data.Focusable = false
+	result.direction = direction
+	result.height = height
+	result.width = width
+	result.position = position
+	result.bcolor = borderColor
+	result.MinFirst = 1
+	result.MinSecond = 1
+	result.ShrinkKey = KeyCtrl | KeyLeft
+	result.GrowKey = KeyCtrl | KeyRight
+	if direction == SplitTopBottom {
+		result.ShrinkKey = KeyCtrl | KeyUp
+		result.GrowKey = KeyCtrl | KeyDown
+	}
+	_, err := ParseColorPair(borderColor)
+	if err != nil {
+		return nil, err
+	}
+	menu.AddElement(&result)
+	return &result, nil
+}
+
+// Sets the panes of the split. A pane can be nil
+func (s *Split) SetPanes(first, second UIElement) error {
+	for _, pane := range []UIElement{first, second} {
+		if pane == nil {
+			continue
+		}
+		err := adopt(s, pane)
+		if err != nil {
+			return err
+		}
+	}
+	s.first = first
+	s.second = second
+	s.Layout()
+	return nil
+}
+
+// Returns the panes of the split
+func (s Split) GetChildren() []UIElement {
+	result := []UIElement{}
+	for _, pane := range []UIElement{s.first, s.second} {
+		if pane != nil {
+			result = append(result, pane)
+		}
+	}
+	return result
+}
+
+// Returns the length of the area in the direction of the split
+func (s Split) length() int {
+	if s.direction == SplitTopBottom {
+		return s.height
+	}
+	return s.width
+}
+
+// Returns the size of the first pane
+func (s Split) GetPosition() int {
+	return s.clampedPosition()
+}
+
+// Sets the size of the first pane. The shown size is kept between the minimum sizes of the panes
+func (s *Split) SetPosition(position int) {
+	s.position = position
+	s.Layout()
+}
+
+// Returns the position kept between the minimum sizes of the panes
+func (s Split) clampedPosition() int {
+	return MaxInt(MinInt(s.position, s.length()-1-s.MinSecond), s.MinFirst, 0)
+}
+
+// Returns the area of the pane relative to the split
+func (s Split) paneArea(first bool) (int, int, int, int) {
+	position := s.clampedPosition()
+	if s.direction == SplitTopBottom {
+		if first {
+			return 0, 0, position, s.width
+		}
+		return position + 1, 0, s.height - position - 1, s.width
+	}
+	if first {
+		return 0, 0, s.height, position
+	}
+	return 0, position + 1, s.height, s.width - position - 1
+}
+
+// Resizes and places the panes
+func (s *Split) Layout() {
+	for i, pane := range []UIElement{s.first, s.second} {
+		if pane == nil {
+			continue
+		}
+		y, x, height, width := s.paneArea(i == 0)
+		if sizable, ok := pane.(Sizable); ok {
+			sizable.SetSize(height, width)
+		}
+		moveElement(pane, s.data.yPos+y, s.data.xPos+x)
+	}
+}
+
+// Returns the area of the pane of the child
+func (s Split) clipArea(child *UIElementData) (int, int, int, int) {
+	y, x, height, width := s.paneArea(s.first != nil && s.first.GetElementData() == child)
+	return s.data.yPos + y, s.data.xPos + x, height, width
+}
+
+// Returns the element data of the split
+func (s Split) GetElementData() *UIElementData {
+	return s.data
+}
+
+// Draws the divider. The panes are drawn by the menu
+func (s Split) Draw(surface Surface) error {
+	color, err := ParseColorPair(s.bcolor)
+	if err != nil {
+		return err
+	}
+	if s.dragging {
+		color = joinAttrs(color, AttrReverse)
+	}
+	position := s.clampedPosition()
+	if s.direction == SplitTopBottom {
+		for i := 0; i < s.width; i++ {
+			surface.SetCell(s.data.yPos+position, s.data.xPos+i, runeHLine, color)
+		}
+		return nil
+	}
+	for i := 0; i < s.height; i++ {
+		surface.SetCell(s.data.yPos+i, s.data.xPos+position, runeVLine, color)
+	}
+	return nil
+}
+
+// On ShrinkKey and GrowKey moves the divider. The keys come from the focused elements of the panes
+func (s *Split) HandleKey(key Key) (bool, error) {
+	switch key {
+	case s.ShrinkKey:
+		s.SetPosition(s.clampedPosition() - 1)
+	case s.GrowKey:
+		s.SetPosition(s.clampedPosition() + 1)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// Pressing the button on the divider starts dragging it, releasing the button stops
+func (s *Split) HandleMouse(event MouseEvent) error {
+	at := event.X
+	if s.direction == SplitTopBottom {
+		at = event.Y
+	}
+	switch event.Action {
+	case MousePress:
+		s.dragging = event.Button == MouseLeft && at == s.clampedPosition()
+	case MouseMove:
+		if s.dragging {
+			s.SetPosition(at)
+		}
+	case MouseRelease:
+		s.dragging = false
+	}
+	return nil
+}
+
+// Returns the height of the split
+func (s Split) Height() int {
+	return s.height
+}
+
+// Returns the width of the split
+func (s Split) Width() int {
+	return s.width
+}
+
+// Sets the height and width of the split. The size of the first pane is kept if the panes fit
+func (s *Split) SetSize(height, width int) {
+	s.height = MaxInt(height, 1)
+	s.width = MaxInt(width, 1)
+}
@@ -0,0 +1,57 @@
+package termui
+
+import "testing"
+
+func TestSplit(t *testing.T) {
+	w, screen := newTestWindow(t, 7, 30)
+	menu := w.GetMenu()
+	split, err := NewSplit(menu, 0, 0, 5, 28, SplitLeftRight, 10, "cyan")
+	must(t, err)
+	left, err := NewPanel(menu, 0, 0, 1, 1, "Left", "normal")
+	must(t, err)
+	right, err := NewPanel(menu, 0, 0, 1, 1, "Right", "normal")
+	must(t, err)
+	button, err := NewButton(menu, 0, 0, "button", func() error {
+		return nil
+	}, KeyEnter)
+	must(t, err)
+	must(t, left.Add(button))
+	must(t, split.SetPanes(left, right))
+	matchGolden(t, w, screen, "split")
+
+	menu.Focus(button)
+	must(t, w.handleKey(split.GrowKey))
+	if split.GetPosition() != 11 || left.Width() != 11 || right.Width() != 16 {
+		t.Fatalf("divider at %v, panes %v and %v wide", split.GetPosition(), left.Width(), right.Width())
+	}
+	expectYX(t, "right", right, 0, 12)
+	// the divider keeps the minimum sizes of the panes
+	split.MinSecond = 5
+	split.SetPosition(100)
+	if split.GetPosition() != 22 {
+		t.Fatalf("divider at %v, want 22", split.GetPosition())
+	}
+	split.SetPosition(-3)
+	must(t, w.handleKey(split.ShrinkKey))
+	if split.GetPosition() != 1 {
+		t.Fatalf("divider at %v, want 1", split.GetPosition())
+	}
+
+	split.SetPosition(10)
+	events := []MouseEvent{
+		// pressing outside the divider doesn't start the drag
+		{Y: 1, X: 3, Button: MouseLeft, Action: MousePress},
+		{Y: 1, X: 5, Button: MouseLeft, Action: MouseMove},
+		{Y: 1, X: 5, Button: MouseLeft, Action: MouseRelease},
+		{Y: 1, X: 10, Button: MouseLeft, Action: MousePress},
+		{Y: 1, X: 15, Button: MouseLeft, Action: MouseMove},
+		{Y: 1, X: 15, Button: MouseLeft, Action: MouseRelease},
+		{Y: 1, X: 20, Button: MouseLeft, Action: MouseMove},
+	}
+	for _, event := range events {
+		must(t, split.HandleMouse(event))
+	}
+	if split.GetPosition() != 15 {
+		t.Fatalf("divider at %v, want 15", split.GetPosition())
+	}
+}
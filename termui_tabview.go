@@ -0,0 +1,290 @@
+package termui
+
+import "fmt"
+
+// A page of the tab view
+type tab struct {
+	cctTitle *CCTMessage
+	children relativeChildren
+	// The element that was focused when the tab was left
+	focused UIElement
+}
+
+// An element with a strip of tabs above a box. Every tab has its own children, only the children of the selected tab are shown.
+// The locations of the children are relative to the inside of the box.
+// NextKey and PrevKey (ctrl+pgdn and ctrl+pgup) switch the tabs, alt+digit selects the tab with the number, a click on the title selects the tab.
+// When the strip is focused, left and right switch the tabs and down focuses the tab
+type TabView struct {
+	data          *UIElementData
+	menu          Menu
+	tabs          []*tab
+	selected      int
+	height, width int
+	bcolor        string
+	onChange      func(tab int)
+	NextKey       Key
+	PrevKey       Key
+}
+
+// Creates a tab view without tabs
+func NewTabView(menu Menu, y, x, height, width int, borderColor string) (*TabView, error) {
+	result := TabView{}
+	result.data = createUIED(y, x)
+	result.menu = menu
+	result.tabs = []*tab{}
+	result.height = height
+	result.width = width
+	result.bcolor = borderColor
+	result.NextKey = KeyCtrl | KeyPageDown
+	result.PrevKey = KeyCtrl | KeyPageUp
+	_, err := ParseColorPair(borderColor)
+	if err != nil {
+		return nil, err
+	}
+	menu.AddElement(&result)
+	return &result, nil
+}
+
+// Adds a tab with the title to the end of the strip. Returns the index of the tab
+func (t *TabView) AddTab(title string) (int, error) {
+	cctTitle, err := ToCCTMessage(title)
+	if err != nil {
+		return 0, err
+	}
+	t.tabs = append(t.tabs, &tab{cctTitle: cctTitle, children: relativeChildren{}})
+	return len(t.tabs) - 1, nil
+}
+
+// Adds the elements to the tab. The locations the elements were created with become relative to the tab view
+func (t *TabView) Add(tabI int, elements ...UIElement) error {
+	if tabI < 0 || tabI >= len(t.tabs) {
+		return fmt.Errorf("termui - tab view has no tab %v", tabI)
+	}
+	err := t.tabs[tabI].children.add(t, elements)
+	if err != nil {
+		return err
+	}
+	t.Layout()
+	return nil
+}
+
+// Returns the index of the selected tab
+func (t TabView) GetSelected() int {
+	return t.selected
+}
+
+// Sets the function that is called after another tab is selected
+func (t *TabView) OnChange(fn func(tab int)) {
+	t.onChange = fn
+}
+
+// Selects the tab. If an element of the previous tab was focused, focuses the element
+// that was focused in the tab, or the first focusable element of the tab, or the strip
+func (t *TabView) Select(tabI int) error {
+	if tabI < 0 || tabI >= len(t.tabs) {
+		return fmt.Errorf("termui - tab view has no tab %v", tabI)
+	}
+	if tabI == t.selected {
+		return nil
+	}
+	focused := focusedElement(t.menu)
+	focusInside := focused != nil && inContainer(focused, t)
+	if focusInside {
+		t.tabs[t.selected].focused = focused
+	}
+	t.selected = tabI
+	if focusInside {
+		t.menu.Focus(t.tabTarget())
+	}
+	if t.onChange != nil {
+		t.onChange(tabI)
+	}
+	return nil
+}
+
+// Returns the element that gets the focus in the selected tab. Without tabs returns the tab view
+func (t *TabView) tabTarget() UIElement {
+	if len(t.tabs) == 0 {
+		return t
+	}
+	tab := t.tabs[t.selected]
+	if tab.focused != nil && canFocus(tab.focused) {
+		return tab.focused
+	}
+	for _, child := range tab.children {
+		if target := focusTarget(child.element, true); target != nil {
+			return target
+		}
+	}
+	return t
+}
+
+// Selects the tab that is step tabs away from the selected one. Wraps around the ends
+func (t *TabView) step(step int) error {
+	if len(t.tabs) == 0 {
+		return nil
+	}
+	return t.Select((t.selected + step + len(t.tabs)) % len(t.tabs))
+}
+
+// Returns the children of all the tabs
+func (t TabView) GetChildren() []UIElement {
+	result := []UIElement{}
+	for _, tab := range t.tabs {
+		result = append(result, tab.children.elements()...)
+	}
+	return result
+}
+
+// Returns true if the child is in the selected tab
+func (t TabView) isChildShown(data *UIElementData) bool {
+	if len(t.tabs) == 0 {
+		return false
+	}
+	for _, child := range t.tabs[t.selected].children {
+		if child.element.GetElementData() == data {
+			return true
+		}
+	}
+	return false
+}
+
+// Sets the location of the child relative to the tab view
+func (t *TabView) placeChild(data *UIElementData, y, x int) {
+	for _, tab := range t.tabs {
+		if tab.children.place(data, y, x) {
+			break
+		}
+	}
+	t.Layout()
+}
+
+// Places the children of all the tabs inside the box
+func (t TabView) Layout() {
+	for _, tab := range t.tabs {
+		tab.children.layout(t.data.yPos+2, t.data.xPos+1)
+	}
+}
+
+// Returns the inside of the box
+func (t TabView) clipArea(child *UIElementData) (int, int, int, int) {
+	return t.data.yPos + 2, t.data.xPos + 1, t.height - 3, t.width - 2
+}
+
+// Returns the columns of the titles on the strip, relative to the tab view
+func (t TabView) titleRanges() [][2]int {
+	result := make([][2]int, 0, len(t.tabs))
+	x := 0
+	for _, tab := range t.tabs {
+		// the title is surrounded by spaces and followed by the separator
+		width := tab.cctTitle.Length() + 2
+		result = append(result, [2]int{x, x + width})
+		x += width + 1
+	}
+	return result
+}
+
+// Returns the element data of the tab view
+func (t TabView) GetElementData() *UIElementData {
+	return t.data
+}
+
+// Draws the strip and the box. The children are drawn by the menu
+func (t TabView) Draw(s Surface) error {
+	s = elementSurface(s, t.data)
+	y, x := t.data.yPos, t.data.xPos
+	clearArea(s, y, x, t.height, t.width)
+	err := DrawBox(s, y+1, x, t.height-1, t.width, t.bcolor)
+	if err != nil {
+		return err
+	}
+	bcolor, err := ParseColorPair(t.bcolor)
+	if err != nil {
+		return err
+	}
+	strip := NewSubSurface(s, y, x, 1, t.width)
+	for i, r := range t.titleRanges() {
+		attr := AttrNormal
+		if i == t.selected {
+			attr = AttrUnderline
+			if t.data.highlighted() {
+				attr = hightlightKey
+			}
+		}
+		Put(strip, 0, r[0], " ", attr)
+		t.tabs[i].cctTitle.Draw(strip, 0, r[0]+1, attr)
+		Put(strip, 0, r[1]-1, " ", attr)
+		strip.SetCell(0, r[1], runeVLine, bcolor)
+	}
+	return nil
+}
+
+// On NextKey and PrevKey switches the tabs, on alt+digit selects the tab with the number.
+// When the strip is focused, left and right switch the tabs and down focuses the selected tab
+func (t *TabView) HandleKey(key Key) (bool, error) {
+	switch {
+	case key == t.NextKey:
+		return true, t.step(1)
+	case key == t.PrevKey:
+		return true, t.step(-1)
+	case key&KeyAlt != 0 && key&^KeyAlt >= '1' && key&^KeyAlt <= '9':
+		i := int(key&^KeyAlt - '1')
+		if i >= len(t.tabs) {
+			return false, nil
+		}
+		return true, t.Select(i)
+	}
+	if !t.data.focused {
+		return false, nil
+	}
+	switch key {
+	case KeyRight:
+		return true, t.step(1)
+	case KeyLeft:
+		return true, t.step(-1)
+	case KeyDown:
+		target := t.tabTarget()
+		if target == t {
+			return false, nil
+		}
+		t.menu.Focus(target)
+		return true, nil
+	}
+	return false, nil
+}
+
+// On click on the title selects the tab. The mouse wheel on the strip switches the tabs
+func (t *TabView) HandleMouse(event MouseEvent) error {
+	if event.Y != 0 {
+		return nil
+	}
+	switch {
+	case event.Button == MouseWheelUp:
+		return t.step(-1)
+	case event.Button == MouseWheelDown:
+		return t.step(1)
+	case event.IsClick():
+		for i, r := range t.titleRanges() {
+			if event.X >= r[0] && event.X < r[1] {
+				return t.Select(i)
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the height of the tab view
+func (t TabView) Height() int {
+	return t.height
+}
+
+// Returns the width of the tab view
+func (t TabView) Width() int {
+	return t.width
+}
+
+// Sets the height and width of the tab view
+func (t *TabView) SetSize(height, width int) {
+	t.height = MaxInt(height, 3)
+	t.width = MaxInt(width, 2)
+}
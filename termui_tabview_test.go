@@ -0,0 +1,67 @@
+package termui
+
+import "testing"
+
+func TestTabView(t *testing.T) {
+	w, screen := newTestWindow(t, 8, 30)
+	menu := w.GetMenu()
+	view, err := NewTabView(menu, 0, 0, 6, 28, "normal")
+	must(t, err)
+	buttons := []*Button{}
+	for i, title := range []string{"${green}First", "Second"} {
+		tabI, err := view.AddTab(title)
+		must(t, err)
+		if tabI != i {
+			t.Fatalf("got tab %v, want %v", tabI, i)
+		}
+		button, err := NewButton(menu, i, 1, "button "+title, func() error {
+			return nil
+		}, KeyEnter)
+		must(t, err)
+		must(t, view.Add(tabI, button))
+		buttons = append(buttons, button)
+	}
+	changes := []int{}
+	view.OnChange(func(tab int) {
+		changes = append(changes, tab)
+	})
+	matchGolden(t, w, screen, "tabview")
+	menu.Focus(buttons[0])
+	// the focus moves to the next tab and comes back to the element that was focused
+	must(t, w.handleKey(view.NextKey))
+	if !buttons[1].GetElementData().focused {
+		t.Fatal("the button of the second tab isn't focused")
+	}
+	matchGolden(t, w, screen, "tabview_second")
+	must(t, w.handleKey(KeyAlt|'1'))
+	if !buttons[0].GetElementData().focused || view.GetSelected() != 0 {
+		t.Fatal("the button of the first tab isn't focused")
+	}
+	// wraps around the ends
+	must(t, w.handleKey(view.PrevKey))
+	if view.GetSelected() != 1 {
+		t.Fatalf("got tab %v, want 1", view.GetSelected())
+	}
+	if len(changes) != 3 {
+		t.Fatalf("got changes %v", changes)
+	}
+	if isShown(buttons[0]) || !isShown(buttons[1]) {
+		t.Fatal("the children of the other tab are shown")
+	}
+	if view.Select(2) == nil || view.Add(-1) == nil {
+		t.Fatal("expected errors for missing tabs")
+	}
+}
+
+func TestTabViewWithoutTabs(t *testing.T) {
+	w, _ := newTestWindow(t, 8, 30)
+	menu := w.GetMenu()
+	view, err := NewTabView(menu, 0, 0, 6, 28, "normal")
+	must(t, err)
+	menu.Focus(view)
+	for _, key := range []Key{KeyDown, KeyRight, KeyLeft, view.NextKey, KeyAlt | '1'} {
+		must(t, w.handleKey(key))
+	}
+	must(t, view.HandleMouse(MouseEvent{Y: 0, X: 1, Button: MouseLeft, Action: MousePress}))
+	must(t, menu.Draw())
+}
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-
-	nc "github.com/rthornton128/goncurses"
 )
 
 type Alignment int
@@ -40,13 +38,13 @@ func CreateListTemplate(options []DrawableAsLine, maxDisplayAmount int) *ListTem
 }
 
 // Draws the list tamplate
-func (l ListTemplate) Draw(win *nc.Window, y, x int, focusSelected bool) error {
+func (l ListTemplate) Draw(s Surface, y, x int, focusSelected bool) error {
 	for i := 0; i < MinInt(l.maxDisplayAmount, len(l.options)); i++ {
-		attr := nc.A_NORMAL
+		attr := AttrNormal
 		if i == l.cursor && focusSelected {
-			attr = nc.A_REVERSE
+			attr = AttrReverse
 		}
-		l.options[i+l.pageN].Draw(win, y+i, x, attr)
+		l.options[i+l.pageN].Draw(s, y+i, x, attr)
 		// put(win, y+i, x, options[i+pageN], attr)
 	}
 	return nil
@@ -110,6 +108,29 @@ func (l *ListTemplate) ScrollDown() {
 	}
 }
 
+// Moves the cursor to the displayed option. Returns false if there is no option at the row
+func (l *ListTemplate) SelectRow(row int) bool {
+	if row < 0 || row >= MinInt(l.maxDisplayAmount, len(l.options)) {
+		return false
+	}
+	l.cursor = row
+	l.choice = l.pageN + row
+	return true
+}
+
+// Sets the amount of the displayed options. Keeps the selected option displayed
+func (l *ListTemplate) SetMaxDisplayAmount(amount int) {
+	if amount < 1 {
+		amount = 1
+	}
+	l.maxDisplayAmount = amount
+	l.pageN = MinInt(l.pageN, l.choice, MaxInt(len(l.options)-amount, 0))
+	if l.choice-l.pageN >= amount {
+		l.pageN = l.choice - amount + 1
+	}
+	l.cursor = l.choice - l.pageN
+}
+
 // Returns the selected element
 func (l ListTemplate) GetSelected() DrawableAsLine {
 	return l.options[l.choice]
@@ -149,6 +170,11 @@ func (l *LineEditTemplate) MoveCursorRight() {
 	}
 }
 
+// Moves the cursor to the position. The cursor doesn't go past the end of the text
+func (l *LineEditTemplate) MoveCursorTo(pos int) {
+	l.cursor = MaxInt(0, MinInt(pos, len(l.content)))
+}
+
 // Adds the character to the cursor location
 func (l *LineEditTemplate) AddCh(ch rune) {
 	if l.cursor < l.maxLen && isValidLineEditCh(ch) {
@@ -158,14 +184,11 @@ func (l *LineEditTemplate) AddCh(ch rune) {
 }
 
 // Draws the line edit template
-func (l LineEditTemplate) Draw(win *nc.Window, yPos, xPos int, focused bool) error {
-	win.MovePrintf(yPos, xPos, l.blank)
-	win.MovePrintf(yPos, xPos, l.content)
+func (l LineEditTemplate) Draw(s Surface, yPos, xPos int, focused bool, attr ...Attr) error {
+	Put(s, yPos, xPos, l.blank, attr...)
+	Put(s, yPos, xPos, l.content, attr...)
 	if focused && l.cursor < l.maxLen {
-		win.Move(yPos, xPos+l.cursor)
-		win.AttrOn(focusedAttribute)
-		win.Print(" ")
-		win.AttrOff(focusedAttribute)
+		Put(s, yPos, xPos+l.cursor, " ", append(attr, focusedAttribute)...)
 	}
 	return nil
 }
@@ -199,7 +222,7 @@ type WordChoiceTemplate struct {
 	options []*CCTMessage
 	choice  int
 	maxLen  int
-	acolor  nc.Char
+	acolor  Attr
 	al      Alignment
 }
 
@@ -249,17 +272,13 @@ func (w *WordChoiceTemplate) FocusPrev() {
 }
 
 // Draws the word choice template
-func (w WordChoiceTemplate) Draw(win *nc.Window, y, x int, focused bool) error {
-	if focused {
-		win.AttrOn(focusedAttribute)
-	}
-	win.AttrOn(w.acolor)
-	win.MoveAddChar(y, x, '<')
-	win.MoveAddChar(y, x+w.maxLen+1, '>')
-	win.AttrOff(w.acolor)
+func (w WordChoiceTemplate) Draw(s Surface, y, x int, focused bool) error {
+	attr := w.acolor
 	if focused {
-		win.AttrOff(focusedAttribute)
+		attr = joinAttrs(focusedAttribute, attr)
 	}
+	s.SetCell(y, x, '<', attr)
+	s.SetCell(y, x+w.maxLen+1, '>', attr)
 	option := w.options[w.choice]
 	xl := x + 1
 	switch w.al {
@@ -268,7 +287,7 @@ func (w WordChoiceTemplate) Draw(win *nc.Window, y, x int, focused bool) error {
 	case AlignRight:
 		xl += (w.maxLen - option.Length())
 	}
-	option.Draw(win, y, xl)
+	option.Draw(s, y, xl)
 	return nil
 }
 
@@ -280,8 +299,8 @@ type ProgressBarTemplate struct {
 	current   int
 	clears    string
 	si        bool
-	bcolor    nc.Char
-	icolor    nc.Char
+	bcolor    Attr
+	icolor    Attr
 }
 
 // Creates a progress bar template
@@ -299,16 +318,25 @@ func CreateProgressBarTemplate(barLength, max int, showInfo bool, barColor strin
 	if err != nil {
 		return nil, err
 	}
-	result.clears = "[" + strings.Repeat(" ", barLength) + "]"
-	if showInfo {
-		ispace := strings.Repeat(" ", len(strconv.Itoa(max)))
-		result.clears += " (" + ispace + "/" + ispace + ")"
-	}
 	result.si = showInfo
 	result.maxs = strconv.Itoa(max)
+	result.SetBarLength(barLength)
 	return &result, nil
 }
 
+// Sets the length of the bar
+func (p *ProgressBarTemplate) SetBarLength(barLength int) {
+	if barLength < 0 {
+		barLength = 0
+	}
+	p.barLength = barLength
+	p.clears = "[" + strings.Repeat(" ", barLength) + "]"
+	if p.si {
+		ispace := strings.Repeat(" ", len(p.maxs))
+		p.clears += " (" + ispace + "/" + ispace + ")"
+	}
+}
+
 // Sets the current value of the template
 func (p *ProgressBarTemplate) Set(value int) {
 	p.current = value
@@ -318,20 +346,18 @@ func (p *ProgressBarTemplate) Set(value int) {
 }
 
 // Draws the template
-func (p ProgressBarTemplate) Draw(win *nc.Window, y, x int) error {
-	win.AttrOn(p.icolor)
+func (p ProgressBarTemplate) Draw(s Surface, y, x int) error {
 	// draw the white space
-	win.MovePrint(y, x, p.clears)
+	Put(s, y, x, p.clears, p.icolor)
 	if p.si {
 		// draw the info
-		win.MovePrint(y, x+p.barLength+4, strconv.Itoa(p.current))
-		win.MovePrint(y, len(p.clears)-len(p.maxs)+1, strconv.Itoa(p.max))
+		Put(s, y, x+p.barLength+4, strconv.Itoa(p.current), p.icolor)
+		Put(s, y, len(p.clears)-len(p.maxs)+1, strconv.Itoa(p.max), p.icolor)
 	}
-	win.AttrOff(p.icolor)
 	// draw the bar
 	l := p.current * p.barLength / p.max
-	s := strings.Repeat(string(progressBarUnit), l)
-	Put(win, y, x+1, s, p.bcolor)
+	bar := strings.Repeat(string(progressBarUnit), l)
+	Put(s, y, x+1, bar, p.bcolor)
 	return nil
 
 }
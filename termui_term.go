@@ -0,0 +1,565 @@
+//go:build linux
+
+package termui
+
+import (
+	"bytes"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unicode/utf8"
+	"unsafe"
+)
+
+const (
+	// Time to wait for the rest of an escape sequence
+	escDelay = 25 * time.Millisecond
+
+	// Turns on the reporting of the mouse buttons and the drags in the SGR format
+	mouseOnSeq = "\x1b[?1000h\x1b[?1002h\x1b[?1006h"
+	// Turns off the reporting of the mouse
+	mouseOffSeq = "\x1b[?1006l\x1b[?1002l\x1b[?1000l"
+	// Beginning of a mouse report in the SGR format, like \x1b[<0;10;5M
+	mouseSeqPrefix = "\x1b[<"
+)
+
+// Size of the terminal, as returned by TIOCGWINSZ
+type termWinsize struct {
+	rows, cols, xpixel, ypixel uint16
+}
+
+// Screen written entirely in Go. Puts the tty into raw mode and draws with terminfo and ANSI sequences.
+// The screen isn't safe for concurrent use: GetKey and LastMouse can be called on one goroutine
+// and the other methods on another one, like the window does, but not more
+type TermScreen struct {
+	in            *os.File
+	out           *os.File
+	oldState      syscall.Termios
+	ti            *terminfo
+	keySeqs       map[string]Key
+	height, width int
+	cells         [][]VirtualCell
+	drawn         [][]VirtualCell
+	pairs         map[int16][2]int16
+	input         chan byte
+	pending       []byte
+	stop          chan struct{}
+	stopPipe      [2]int
+	winch         chan os.Signal
+	resized       int32
+	cursorY       int
+	cursorX       int
+	cursorVisible bool
+	mouse         MouseEvent
+	suspended     bool
+	ended         bool
+}
+
+// Puts the terminal into raw mode and creates the screen (should only be called once)
+func NewTermScreen() (*TermScreen, error) {
+	result := TermScreen{}
+	result.in = os.Stdin
+	result.out = os.Stdout
+	// terminfo is optional, ANSI sequences are used without it
+	result.ti, _ = loadTerminfo(os.Getenv("TERM"))
+	result.keySeqs = result.ti.keySeqs()
+	result.pairs = map[int16][2]int16{}
+	result.cursorVisible = true
+	err := ioctl(result.in.Fd(), syscall.TCGETS, unsafe.Pointer(&result.oldState))
+	if err != nil {
+		return nil, err
+	}
+	err = result.enter()
+	if err != nil {
+		return nil, err
+	}
+	result.updateSize()
+	result.input = make(chan byte, 128)
+	result.stop = make(chan struct{})
+	// the pipe wakes up the reader of the input when the screen is ended
+	err = syscall.Pipe(result.stopPipe[:])
+	if err != nil {
+		result.leave()
+		return nil, err
+	}
+	go result.readInput()
+	result.winch = make(chan os.Signal, 1)
+	signal.Notify(result.winch, syscall.SIGWINCH)
+	return &result, nil
+}
+
+// Puts the terminal into raw mode and switches to the alternate screen
+func (s *TermScreen) enter() error {
+	raw := s.oldState
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Oflag &^= syscall.OPOST
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	err := ioctl(s.in.Fd(), syscall.TCSETS, unsafe.Pointer(&raw))
+	if err != nil {
+		return err
+	}
+	s.out.WriteString(s.ti.get("smcup") + s.ti.get("smkx") + s.ti.get("clear") + mouseOnSeq)
+	if !s.cursorVisible {
+		s.out.WriteString(s.ti.get("civis"))
+	}
+	return nil
+}
+
+// Switches back to the normal screen and restores the modes of the terminal
+func (s *TermScreen) leave() {
+	s.out.WriteString(mouseOffSeq + s.ti.get("sgr0") + s.ti.get("cnorm") + s.ti.get("rmkx") + s.ti.get("rmcup"))
+	ioctl(s.in.Fd(), syscall.TCSETS, unsafe.Pointer(&s.oldState))
+}
+
+// Calls ioctl on the file descriptor
+func ioctl(fd uintptr, request uintptr, arg unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// Reads the size of the terminal and resizes the cells
+func (s *TermScreen) updateSize() {
+	ws := termWinsize{}
+	err := ioctl(s.out.Fd(), syscall.TIOCGWINSZ, unsafe.Pointer(&ws))
+	if err != nil || ws.rows == 0 || ws.cols == 0 {
+		ws.rows, ws.cols = 24, 80
+	}
+	s.height = int(ws.rows)
+	s.width = int(ws.cols)
+	s.cells = make([][]VirtualCell, s.height)
+	s.drawn = make([][]VirtualCell, s.height)
+	for i := range s.cells {
+		s.cells[i] = make([]VirtualCell, s.width)
+		s.drawn[i] = make([]VirtualCell, s.width)
+		for j := range s.cells[i] {
+			s.cells[i][j] = VirtualCell{Ch: ' ', Attr: AttrNormal}
+			s.drawn[i][j] = VirtualCell{Ch: ' ', Attr: AttrNormal}
+		}
+	}
+}
+
+// Adds the file descriptor to the set
+func fdSet(set *syscall.FdSet, fd int) {
+	bits := int(8 * unsafe.Sizeof(set.Bits[0]))
+	set.Bits[fd/bits] |= 1 << (fd % bits)
+}
+
+// Returns true if the file descriptor is in the set
+func fdIsSet(set *syscall.FdSet, fd int) bool {
+	bits := int(8 * unsafe.Sizeof(set.Bits[0]))
+	return set.Bits[fd/bits]&(1<<(fd%bits)) != 0
+}
+
+// Reads the bytes of the terminal until the screen is ended
+func (s *TermScreen) readInput() {
+	defer close(s.input)
+	defer syscall.Close(s.stopPipe[0])
+	in := int(s.in.Fd())
+	buf := make([]byte, 128)
+	for {
+		// waits for the input or for End, so that nothing is read after the terminal is restored
+		set := syscall.FdSet{}
+		fdSet(&set, in)
+		fdSet(&set, s.stopPipe[0])
+		nfd := in
+		if s.stopPipe[0] > nfd {
+			nfd = s.stopPipe[0]
+		}
+		_, err := syscall.Select(nfd+1, &set, nil, nil, nil)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil || fdIsSet(&set, s.stopPipe[0]) {
+			return
+		}
+		n, err := s.in.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, b := range buf[:n] {
+			select {
+			case s.input <- b:
+			case <-s.stop:
+				return
+			}
+		}
+	}
+}
+
+// Applies the size change of the terminal, if there was any
+func (s *TermScreen) applyResize() {
+	if atomic.SwapInt32(&s.resized, 0) == 1 {
+		s.updateSize()
+		s.out.WriteString(s.ti.get("clear"))
+	}
+}
+
+// Returns the height and width of the screen
+func (s *TermScreen) MaxYX() (int, int) {
+	s.applyResize()
+	return s.height, s.width
+}
+
+// Puts the character with the attribute at the location. Characters outside the screen are ignored
+func (s *TermScreen) SetCell(y, x int, ch rune, attr Attr) {
+	if y < 0 || x < 0 || y >= s.height || x >= s.width {
+		return
+	}
+	s.cells[y][x] = VirtualCell{Ch: ch, Attr: attr}
+}
+
+// Fills the screen with spaces
+func (s *TermScreen) Erase() {
+	s.applyResize()
+	for i := range s.cells {
+		for j := range s.cells[i] {
+			s.cells[i][j] = VirtualCell{Ch: ' ', Attr: AttrNormal}
+		}
+	}
+}
+
+// Returns the SGR parameter of the color
+func sgrColor(color int16, base, brightBase, extended int) string {
+	switch {
+	case color < 0:
+		return strconv.Itoa(base + 9)
+	case color < 8:
+		return strconv.Itoa(base + int(color))
+	case color < 16:
+		return strconv.Itoa(brightBase + int(color) - 8)
+	}
+	return strconv.Itoa(extended) + ";5;" + strconv.Itoa(int(color))
+}
+
+// Returns the SGR sequence of the attribute
+func (s TermScreen) sgr(attr Attr) string {
+	params := []string{"0"}
+	if attr&AttrBold != 0 {
+		params = append(params, "1")
+	}
+	if attr&AttrDim != 0 {
+		params = append(params, "2")
+	}
+	if attr&AttrUnderline != 0 {
+		params = append(params, "4")
+	}
+	if attr&AttrReverse != 0 {
+		params = append(params, "7")
+	}
+	if pair := attr.Pair(); pair != 0 {
+		colors := s.pairs[pair]
+		params = append(params, sgrColor(colors[0], 30, 90, 38), sgrColor(colors[1], 40, 100, 48))
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// Writes the changed cells to the terminal
+func (s *TermScreen) Refresh() {
+	if s.ended || s.suspended {
+		return
+	}
+	buf := bytes.Buffer{}
+	attr := Attr(0)
+	buf.WriteString(s.sgr(attr))
+	curY, curX := -1, -1
+	for y, row := range s.cells {
+		for x, cell := range row {
+			if cell == s.drawn[y][x] {
+				continue
+			}
+			if curY != y || curX != x {
+				buf.WriteString("\x1b[" + strconv.Itoa(y+1) + ";" + strconv.Itoa(x+1) + "H")
+			}
+			if cell.Attr != attr {
+				attr = cell.Attr
+				buf.WriteString(s.sgr(attr))
+			}
+			buf.WriteRune(cell.Ch)
+			s.drawn[y][x] = cell
+			curY, curX = y, x+1
+		}
+	}
+	buf.WriteString(s.sgr(AttrNormal))
+	if s.cursorVisible {
+		buf.WriteString("\x1b[" + strconv.Itoa(s.cursorY+1) + ";" + strconv.Itoa(s.cursorX+1) + "H")
+	}
+	s.out.Write(buf.Bytes())
+}
+
+// Moves the cursor to the location
+func (s *TermScreen) MoveCursor(y, x int) {
+	s.cursorY = y
+	s.cursorX = x
+}
+
+// Shows or hides the cursor
+func (s *TermScreen) SetCursorVisible(visible bool) {
+	s.cursorVisible = visible
+	if visible {
+		s.out.WriteString(s.ti.get("cnorm"))
+		return
+	}
+	s.out.WriteString(s.ti.get("civis"))
+}
+
+// Returns the next byte of the input. If wait is positive, waits for the byte only that long
+func (s *TermScreen) readByte(wait time.Duration) (byte, bool) {
+	if len(s.pending) > 0 {
+		result := s.pending[0]
+		s.pending = s.pending[1:]
+		return result, true
+	}
+	if wait <= 0 {
+		b, ok := <-s.input
+		return b, ok
+	}
+	select {
+	case b, ok := <-s.input:
+		return b, ok
+	case <-time.After(wait):
+		return 0, false
+	}
+}
+
+// Returns true if seq is the beginning of a key sequence
+func (s TermScreen) isSeqPrefix(seq string) bool {
+	for known := range s.keySeqs {
+		if len(known) > len(seq) && strings.HasPrefix(known, seq) {
+			return true
+		}
+	}
+	return false
+}
+
+// Blocks until a key is pressed and decodes it.
+// If the terminal is resized, returns KeyResize.
+// If the input is closed, returns KeyEscape
+func (s *TermScreen) GetKey() Key {
+	if len(s.pending) == 0 {
+		select {
+		case <-s.winch:
+			// the size is updated on the drawing goroutine
+			atomic.StoreInt32(&s.resized, 1)
+			return KeyResize
+		case b, ok := <-s.input:
+			if !ok {
+				return KeyEscape
+			}
+			s.pending = append(s.pending, b)
+		}
+	}
+	b, _ := s.readByte(0)
+	seq := s.readSequence(string([]byte{b}))
+	if seq == mouseSeqPrefix && s.readMouse() {
+		return KeyMouse
+	}
+	if key, has := s.keySeqs[seq]; has {
+		return key
+	}
+	if seq == "\x1b\x1b" {
+		next, ok := s.readByte(escDelay)
+		if ok && (next == '[' || next == 'O') {
+			// escape followed by the sequence of a key is sent by alt + the key
+			altSeq := s.readSequence("\x1b" + string([]byte{next}))
+			if key, has := s.keySeqs[altSeq]; has {
+				return KeyAlt | key
+			}
+			if altSeq == mouseSeqPrefix && s.readMouse() {
+				return KeyMouse
+			}
+			s.skipSequence(altSeq)
+			return s.GetKey()
+		}
+		if ok {
+			s.pending = append([]byte{next}, s.pending...)
+		}
+	}
+	if len(seq) == 2 && b == 27 && seq[1] >= ' ' && seq[1] < 127 {
+		// escape followed by a character is sent by alt + the character
+		return KeyAlt | Key(seq[1])
+	}
+	if len(seq) > 2 && b == 27 && (seq[1] == '[' || seq[1] == 'O') {
+		// unknown sequence, like alt + an arrow, is skipped whole
+		s.skipSequence(seq)
+		return s.GetKey()
+	}
+	if len(seq) > 1 {
+		// otherwise the first byte is returned on its own
+		s.pending = append([]byte(seq[1:]), s.pending...)
+	}
+	switch b {
+	case '\r', '\n':
+		return KeyEnter
+	case 127, 8:
+		return KeyBackspace
+	}
+	if b < utf8.RuneSelf {
+		return Key(b)
+	}
+	// read the rest of the utf-8 character
+	runeBytes := []byte{b}
+	for !utf8.FullRune(runeBytes) && len(runeBytes) < utf8.UTFMax {
+		next, ok := s.readByte(escDelay)
+		if !ok {
+			break
+		}
+		runeBytes = append(runeBytes, next)
+	}
+	ch, _ := utf8.DecodeRune(runeBytes)
+	return Key(ch)
+}
+
+// Reads the bytes that continue seq as long as it is the beginning of a key sequence
+func (s *TermScreen) readSequence(seq string) string {
+	for s.isSeqPrefix(seq) {
+		next, ok := s.readByte(escDelay)
+		if !ok {
+			break
+		}
+		seq += string([]byte{next})
+	}
+	return seq
+}
+
+// Reads the rest of the unknown escape sequence.
+// CSI sequences end with a byte in the range @ to ~, SS3 sequences with the byte after the O
+func (s *TermScreen) skipSequence(seq string) {
+	switch seq[1] {
+	case '[':
+		last := seq[len(seq)-1]
+		for len(seq) == 2 || last < '@' || last > '~' {
+			next, ok := s.readByte(escDelay)
+			if !ok {
+				return
+			}
+			seq += string([]byte{next})
+			last = next
+		}
+	case 'O':
+		if len(seq) == 2 {
+			s.readByte(escDelay)
+		}
+	}
+}
+
+// Reads the rest of the SGR mouse report. Returns false if the report is malformed
+func (s *TermScreen) readMouse() bool {
+	report := ""
+	for {
+		next, ok := s.readByte(escDelay)
+		if !ok {
+			return false
+		}
+		report += string([]byte{next})
+		if next == 'M' || next == 'm' {
+			break
+		}
+	}
+	params := strings.Split(report[:len(report)-1], ";")
+	if len(params) != 3 {
+		return false
+	}
+	values := make([]int, 0, 3)
+	for _, param := range params {
+		value, err := strconv.Atoi(param)
+		if err != nil {
+			return false
+		}
+		values = append(values, value)
+	}
+	code := values[0]
+	// the terminal counts from 1
+	event := MouseEvent{Y: values[2] - 1, X: values[1] - 1, Action: MousePress}
+	switch {
+	case code&64 != 0:
+		event.Button = MouseWheelUp
+		if code&1 != 0 {
+			event.Button = MouseWheelDown
+		}
+	default:
+		event.Button = []MouseButton{MouseLeft, MouseMiddle, MouseRight, MouseNone}[code&3]
+		if code&32 != 0 {
+			event.Action = MouseMove
+		}
+	}
+	if report[len(report)-1] == 'm' {
+		event.Action = MouseRelease
+	}
+	s.mouse = event
+	return true
+}
+
+// Returns the mouse event of the last KeyMouse
+func (s *TermScreen) LastMouse() MouseEvent {
+	return s.mouse
+}
+
+// Records the color pair
+func (s *TermScreen) InitPair(pair, fg, bg int16) error {
+	s.pairs[pair] = [2]int16{fg, bg}
+	return nil
+}
+
+// Rings the bell
+func (s *TermScreen) Beep() {
+	s.out.WriteString(s.ti.get("bel"))
+}
+
+// Flashes the screen
+func (s *TermScreen) Flash() {
+	s.out.WriteString(s.ti.get("flash"))
+}
+
+// Restores the terminal
+func (s *TermScreen) End() {
+	if s.ended {
+		return
+	}
+	s.ended = true
+	signal.Stop(s.winch)
+	close(s.stop)
+	syscall.Close(s.stopPipe[1])
+	if !s.suspended {
+		s.leave()
+	}
+}
+
+// Restores the modes of the terminal until Resume is called
+func (s *TermScreen) Suspend() {
+	if s.ended || s.suspended {
+		return
+	}
+	s.suspended = true
+	s.leave()
+}
+
+// Puts the terminal back into raw mode. The screen is redrawn after KeyResize
+func (s *TermScreen) Resume() {
+	if s.ended {
+		return
+	}
+	s.suspended = false
+	s.enter()
+	// everything is written on the next refresh
+	for y := range s.drawn {
+		for x := range s.drawn[y] {
+			s.drawn[y][x] = VirtualCell{}
+		}
+	}
+	// the size could have changed while the process was stopped
+	select {
+	case s.winch <- syscall.SIGWINCH:
+	default:
+	}
+}
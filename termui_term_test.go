@@ -0,0 +1,63 @@
+//go:build linux
+
+package termui
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestTermScreenGetKey(t *testing.T) {
+	r, w, err := os.Pipe()
+	must(t, err)
+	defer w.Close()
+	s := &TermScreen{in: r, out: os.Stdout, keySeqs: (*terminfo)(nil).keySeqs()}
+	s.input = make(chan byte, 128)
+	s.stop = make(chan struct{})
+	must(t, syscall.Pipe(s.stopPipe[:]))
+	go s.readInput()
+	defer close(s.stop)
+	defer syscall.Close(s.stopPipe[1])
+	// unknown sequences are skipped whole, even after the escape of alt.
+	// The lone escape goes last as it waits for a sequence
+	_, err = w.WriteString("\x1b[A\x1bx\rж\x1b[1;3Aq\x1bOzq\x1b\x1b[A\x1b\x1b[1;3Bq\x1b\x1bq\x1b")
+	must(t, err)
+	for _, want := range []Key{KeyUp, KeyAlt | 'x', KeyEnter, 'ж', 'q', 'q', KeyAlt | KeyUp, 'q', KeyEscape, KeyAlt | 'q', KeyEscape} {
+		if got := s.GetKey(); got != want {
+			t.Fatalf("got %s, want %s", KeyName(got), KeyName(want))
+		}
+	}
+}
+
+func TestTermScreenEndStopsInput(t *testing.T) {
+	r, w, err := os.Pipe()
+	must(t, err)
+	defer w.Close()
+	out, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	must(t, err)
+	defer out.Close()
+	s := &TermScreen{in: r, out: out}
+	s.input = make(chan byte, 128)
+	s.stop = make(chan struct{})
+	must(t, syscall.Pipe(s.stopPipe[:]))
+	go s.readInput()
+	s.End()
+	select {
+	case _, ok := <-s.input:
+		if ok {
+			t.Fatal("input was read after End")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("input is not closed after End")
+	}
+	// the byte is left for the next reader of the terminal
+	_, err = w.WriteString("q")
+	must(t, err)
+	buf := make([]byte, 1)
+	n, err := s.in.Read(buf)
+	if err != nil || n != 1 || buf[0] != 'q' {
+		t.Fatalf("got %q, %v", buf[:n], err)
+	}
+}
@@ -0,0 +1,268 @@
+package termui
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+const (
+	terminfoMagic         = 0432
+	terminfoExtendedMagic = 01036
+)
+
+var (
+	// Indexes of the used string capabilities in the compiled terminfo file
+	terminfoStringCaps = map[string]int{
+		"bel":   1,
+		"clear": 5,
+		"civis": 13,
+		"cnorm": 16,
+		"smcup": 28,
+		"sgr0":  39,
+		"rmcup": 40,
+		"flash": 45,
+		"kbs":   55,
+		"kdch1": 59,
+		"kcud1": 61,
+		"kf1":   66,
+		"kf10":  67,
+		"kf2":   68,
+		"kf3":   69,
+		"kf4":   70,
+		"kf5":   71,
+		"kf6":   72,
+		"kf7":   73,
+		"kf8":   74,
+		"kf9":   75,
+		"khome": 76,
+		"kich1": 77,
+		"kcub1": 79,
+		"knp":   81,
+		"kpp":   82,
+		"kcuf1": 83,
+		"kcuu1": 87,
+		"rmkx":  88,
+		"smkx":  89,
+		"kcbt":  148,
+		"kend":  164,
+		"kf11":  216,
+		"kf12":  217,
+	}
+	// Keys of the key capabilities
+	terminfoKeyCaps = map[string]Key{
+		"kbs":   KeyBackspace,
+		"kdch1": KeyDelete,
+		"kcud1": KeyDown,
+		"kf1":   KeyF1,
+		"kf2":   KeyF2,
+		"kf3":   KeyF3,
+		"kf4":   KeyF4,
+		"kf5":   KeyF5,
+		"kf6":   KeyF6,
+		"kf7":   KeyF7,
+		"kf8":   KeyF8,
+		"kf9":   KeyF9,
+		"kf10":  KeyF10,
+		"kf11":  KeyF11,
+		"kf12":  KeyF12,
+		"khome": KeyHome,
+		"kich1": KeyInsert,
+		"kcub1": KeyLeft,
+		"knp":   KeyPageDown,
+		"kpp":   KeyPageUp,
+		"kcuf1": KeyRight,
+		"kcuu1": KeyUp,
+		"kcbt":  KeyBackTab,
+		"kend":  KeyEnd,
+	}
+	// Capabilities of an ANSI terminal, used when terminfo can't be loaded
+	ansiCaps = map[string]string{
+		"bel":   "\a",
+		"clear": "\x1b[H\x1b[2J",
+		"civis": "\x1b[?25l",
+		"cnorm": "\x1b[?25h",
+		"smcup": "\x1b[?1049h",
+		"sgr0":  "\x1b[m",
+		"rmcup": "\x1b[?1049l",
+		"flash": "\x1b[?5h\x1b[?5l",
+		"rmkx":  "\x1b[?1l\x1b>",
+		"smkx":  "\x1b[?1h\x1b=",
+	}
+	// Key sequences of ANSI terminals. Recognized in addition to the terminfo sequences
+	ansiKeySeqs = map[string]Key{
+		"\x1b[A":     KeyUp,
+		"\x1b[B":     KeyDown,
+		"\x1b[C":     KeyRight,
+		"\x1b[D":     KeyLeft,
+		"\x1bOA":     KeyUp,
+		"\x1bOB":     KeyDown,
+		"\x1bOC":     KeyRight,
+		"\x1bOD":     KeyLeft,
+		"\x1b[H":     KeyHome,
+		"\x1b[F":     KeyEnd,
+		"\x1bOH":     KeyHome,
+		"\x1bOF":     KeyEnd,
+		"\x1b[1~":    KeyHome,
+		"\x1b[2~":    KeyInsert,
+		"\x1b[3~":    KeyDelete,
+		"\x1b[4~":    KeyEnd,
+		"\x1b[5~":    KeyPageUp,
+		"\x1b[6~":    KeyPageDown,
+		"\x1b[Z":     KeyBackTab,
+		"\x1bOP":     KeyF1,
+		"\x1bOQ":     KeyF2,
+		"\x1bOR":     KeyF3,
+		"\x1bOS":     KeyF4,
+		"\x1b[15~":   KeyF5,
+		"\x1b[17~":   KeyF6,
+		"\x1b[18~":   KeyF7,
+		"\x1b[19~":   KeyF8,
+		"\x1b[20~":   KeyF9,
+		"\x1b[21~":   KeyF10,
+		"\x1b[23~":   KeyF11,
+		"\x1b[24~":   KeyF12,
+		"\x1b[1;5A":  KeyCtrl | KeyUp,
+		"\x1b[1;5B":  KeyCtrl | KeyDown,
+		"\x1b[1;5C":  KeyCtrl | KeyRight,
+		"\x1b[1;5D":  KeyCtrl | KeyLeft,
+		"\x1b[1;5H":  KeyCtrl | KeyHome,
+		"\x1b[1;5F":  KeyCtrl | KeyEnd,
+		"\x1b[5;5~":  KeyCtrl | KeyPageUp,
+		"\x1b[6;5~":  KeyCtrl | KeyPageDown,
+		"\x1b[1;2A":  KeyShiftUp,
+		"\x1b[1;2B":  KeyShiftDown,
+		"\x1b[1;2C":  KeyShiftRight,
+		"\x1b[1;2D":  KeyShiftLeft,
+		"\x1b[1;2H":  KeyShiftHome,
+		"\x1b[1;2F":  KeyShiftEnd,
+		"\x1b[1;2P":  KeyShiftF1,
+		"\x1b[1;2Q":  KeyShiftF1 + 1,
+		"\x1b[1;2R":  KeyShiftF1 + 2,
+		"\x1b[1;2S":  KeyShiftF1 + 3,
+		"\x1b[15;2~": KeyShiftF1 + 4,
+		"\x1b[17;2~": KeyShiftF1 + 5,
+		"\x1b[18;2~": KeyShiftF1 + 6,
+		"\x1b[19;2~": KeyShiftF1 + 7,
+		"\x1b[20;2~": KeyShiftF1 + 8,
+		"\x1b[21;2~": KeyShiftF1 + 9,
+		"\x1b[23;2~": KeyShiftF1 + 10,
+		"\x1b[24;2~": KeyShiftF1 + 11,
+	}
+
+	// Matches the padding of the capabilities, like $<100/>
+	terminfoPaddingRegex = regexp.MustCompile(`\$<[\d.*/]*>`)
+)
+
+// The string capabilities of a terminal
+type terminfo struct {
+	caps map[string]string
+}
+
+// Returns the directories that may contain the terminfo files
+func terminfoDirs() []string {
+	result := []string{}
+	if dir := os.Getenv("TERMINFO"); dir != "" {
+		result = append(result, dir)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		result = append(result, filepath.Join(home, ".terminfo"))
+	}
+	if dirs := os.Getenv("TERMINFO_DIRS"); dirs != "" {
+		result = append(result, filepath.SplitList(dirs)...)
+	}
+	return append(result, "/etc/terminfo", "/lib/terminfo", "/usr/share/terminfo", "/usr/lib/terminfo")
+}
+
+// Loads the terminfo of the terminal
+func loadTerminfo(term string) (*terminfo, error) {
+	if term == "" {
+		return nil, errors.New("termui - TERM is not set")
+	}
+	for _, dir := range terminfoDirs() {
+		for _, sub := range []string{term[:1], fmt.Sprintf("%x", term[0])} {
+			data, err := os.ReadFile(filepath.Join(dir, sub, term))
+			if err == nil {
+				return parseTerminfo(data)
+			}
+		}
+	}
+	return nil, fmt.Errorf("termui - can't find terminfo of %v", term)
+}
+
+// Parses the compiled terminfo file
+func parseTerminfo(data []byte) (*terminfo, error) {
+	if len(data) < 12 {
+		return nil, errors.New("termui - terminfo file is too short")
+	}
+	header := make([]int, 6)
+	for i := range header {
+		header[i] = int(int16(binary.LittleEndian.Uint16(data[i*2:])))
+	}
+	numberSize := 2
+	switch header[0] {
+	case terminfoMagic:
+	case terminfoExtendedMagic:
+		numberSize = 4
+	default:
+		return nil, fmt.Errorf("termui - %o is not a terminfo magic number", header[0])
+	}
+	namesSize, boolCount, numberCount, stringCount, tableSize := header[1], header[2], header[3], header[4], header[5]
+	offset := 12 + namesSize + boolCount
+	if offset%2 == 1 {
+		offset++
+	}
+	offset += numberCount * numberSize
+	tableOffset := offset + stringCount*2
+	if tableOffset+tableSize > len(data) {
+		return nil, errors.New("termui - terminfo file is truncated")
+	}
+	table := data[tableOffset : tableOffset+tableSize]
+	result := terminfo{}
+	result.caps = map[string]string{}
+	for name, i := range terminfoStringCaps {
+		if i >= stringCount {
+			continue
+		}
+		capOffset := int(int16(binary.LittleEndian.Uint16(data[offset+i*2:])))
+		if capOffset < 0 || capOffset >= len(table) {
+			continue
+		}
+		end := capOffset
+		for end < len(table) && table[end] != 0 {
+			end++
+		}
+		result.caps[name] = terminfoPaddingRegex.ReplaceAllString(string(table[capOffset:end]), "")
+	}
+	return &result, nil
+}
+
+// Returns the capability. If the terminal doesn't have it, returns the ANSI capability
+func (t *terminfo) get(name string) string {
+	if t != nil {
+		if result, has := t.caps[name]; has {
+			return result
+		}
+	}
+	return ansiCaps[name]
+}
+
+// Returns the key sequences of the terminal
+func (t *terminfo) keySeqs() map[string]Key {
+	result := map[string]Key{}
+	for seq, key := range ansiKeySeqs {
+		result[seq] = key
+	}
+	if t == nil {
+		return result
+	}
+	for name, key := range terminfoKeyCaps {
+		if seq, has := t.caps[name]; has && seq != "" {
+			result[seq] = key
+		}
+	}
+	return result
+}
@@ -0,0 +1,262 @@
+package termui
+
+import (
+	"context"
+	"flag"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// Creates a window drawn on a virtual screen of the size
+func newTestWindow(t *testing.T, height, width int) (*Window, *VirtualScreen) {
+	t.Helper()
+	screen := NewVirtualScreen(height, width)
+	w, err := CreateWindowWithScreen(screen, "Test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w, screen
+}
+
+// Draws the window and compares the screen with the golden file testdata/name.golden
+func matchGolden(t *testing.T, w *Window, screen *VirtualScreen, name string) {
+	t.Helper()
+	err := w.draw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = screen.MatchGolden(filepath.Join("testdata", name+".golden"), *update)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Fails the test if the error isn't nil
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Virtual screen that counts the calls of End
+type endCounter struct {
+	*VirtualScreen
+	ends int32
+}
+
+func (s *endCounter) End() {
+	atomic.AddInt32(&s.ends, 1)
+	s.VirtualScreen.End()
+}
+
+func TestStartContext(t *testing.T) {
+	screen := &endCounter{VirtualScreen: NewVirtualScreen(5, 20)}
+	w, err := CreateWindowWithScreen(screen, "Test")
+	must(t, err)
+	w.SetInput(make(chanInput))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- w.StartContext(ctx)
+	}()
+	cancel()
+	err = <-done
+	if err != context.Canceled {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+	w.Exit()
+	if ends := atomic.LoadInt32(&screen.ends); ends != 1 {
+		t.Fatalf("the screen was ended %v times", ends)
+	}
+}
+
+func TestStartContextExitRace(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		screen := &endCounter{VirtualScreen: NewVirtualScreen(5, 20)}
+		w, err := CreateWindowWithScreen(screen, "Test")
+		must(t, err)
+		w.SetInput(make(chanInput))
+		ctx, cancel := context.WithCancel(context.Background())
+		go cancel()
+		go w.QueueUpdate(w.Exit)
+		err = w.StartContext(ctx)
+		if err != nil && err != context.Canceled {
+			t.Fatal(err)
+		}
+		if ends := atomic.LoadInt32(&screen.ends); ends != 1 {
+			t.Fatalf("the screen was ended %v times", ends)
+		}
+	}
+}
+
+func TestDialogAfterExit(t *testing.T) {
+	w, _ := newTestWindow(t, 12, 40)
+	w.SetInput(make(chanInput))
+	w.QueueUpdate(w.Exit)
+	_, err := MessageBox(w, "Save?", []string{"Yes", "No"}, "normal")
+	if err != ErrWindowExited {
+		t.Fatalf("got %v, want %v", err, ErrWindowExited)
+	}
+}
+
+// Element that handles only its own keys and records every key it gets
+type keyRecorder struct {
+	data    *UIElementData
+	handles Key
+	keys    []Key
+}
+
+func newKeyRecorder(menu Menu, handles Key) *keyRecorder {
+	result := keyRecorder{}
+	result.data = createUIED(0, 0)
+	result.handles = handles
+	menu.AddElement(&result)
+	return &result
+}
+
+func (r *keyRecorder) GetElementData() *UIElementData {
+	return r.data
+}
+
+func (r *keyRecorder) Draw(s Surface) error {
+	return nil
+}
+
+func (r *keyRecorder) HandleKey(key Key) (bool, error) {
+	r.keys = append(r.keys, key)
+	return key == r.handles, nil
+}
+
+func (r *keyRecorder) Height() int {
+	return 1
+}
+
+func (r *keyRecorder) Width() int {
+	return 1
+}
+
+func TestKeyBubbling(t *testing.T) {
+	w, _ := newTestWindow(t, 10, 30)
+	menu := w.GetMenu().(*NormalMenu)
+	panel, err := NewPanel(menu, 0, 0, 5, 10, "Panel", "normal")
+	must(t, err)
+	recorder := newKeyRecorder(menu, 'h')
+	must(t, panel.Add(recorder))
+	menu.Focus(recorder)
+	called := ""
+	bind := func(name string) func() error {
+		return func() error {
+			called = name
+			return nil
+		}
+	}
+	must(t, Bind(panel, "p", bind("panel")))
+	must(t, menu.Bind("m", bind("menu")))
+	must(t, menu.Bind("h", bind("menu")))
+	must(t, w.Bind("w", bind("window")))
+	must(t, w.Bind("h", bind("window")))
+	for _, tt := range []struct {
+		key  Key
+		want string
+	}{
+		{'h', ""},
+		{'p', "panel"},
+		{'m', "menu"},
+		{'w', "window"},
+		{'x', ""},
+	} {
+		called = ""
+		must(t, w.handleKey(tt.key))
+		if called != tt.want {
+			t.Errorf("%s: called the binding of the %q, want %q", KeyName(tt.key), called, tt.want)
+		}
+	}
+	// the focused element sees every key first
+	if !keysEqual(recorder.keys, []Key{'h', 'p', 'm', 'w', 'x'}) {
+		t.Fatalf("the element got %v", recorder.keys)
+	}
+}
+
+func TestHandledKeyStopsQuit(t *testing.T) {
+	w, _ := newTestWindow(t, 10, 30)
+	menu := w.GetMenu()
+	recorder := newKeyRecorder(menu, KeyEscape)
+	menu.Focus(recorder)
+	exits := 0
+	w.BeforeExit(func() bool {
+		exits++
+		return false
+	})
+	must(t, w.handleKey(KeyEscape))
+	if exits != 0 {
+		t.Fatal("the key handled by the element requested the exit")
+	}
+	recorder.handles = KeyNone
+	must(t, w.handleKey(KeyEscape))
+	if exits != 1 {
+		t.Fatal("the unhandled quit key didn't request the exit")
+	}
+}
+
+func TestDisabledElementGetsNoKeys(t *testing.T) {
+	w, _ := newTestWindow(t, 10, 30)
+	menu := w.GetMenu()
+	recorder := newKeyRecorder(menu, 'h')
+	menu.Focus(recorder)
+	recorder.data.Enabled = false
+	called := false
+	must(t, w.Bind("h", func() error {
+		called = true
+		return nil
+	}))
+	must(t, w.handleKey('h'))
+	if len(recorder.keys) != 0 || !called {
+		t.Fatal("the key went to the disabled element")
+	}
+}
+
+func TestExitWithResult(t *testing.T) {
+	w, screen := newTestWindow(t, 5, 20)
+	button, err := NewButton(w.GetMenu(), 0, 0, "Done", func() error {
+		w.ExitWith(42)
+		return nil
+	}, KeyEnter)
+	must(t, err)
+	w.GetMenu().Focus(button)
+	exits := 0
+	w.OnExit(func() {
+		exits++
+	})
+	screen.PushKeys(KeyEnter)
+	must(t, w.Start())
+	if w.Result() != 42 {
+		t.Fatalf("got result %v, want 42", w.Result())
+	}
+	if exits != 1 || !screen.Ended() {
+		t.Fatalf("the exit hooks were called %d times, the screen ended: %v", exits, screen.Ended())
+	}
+}
+
+func TestQuitKey(t *testing.T) {
+	w, screen := newTestWindow(t, 5, 20)
+	menu := w.GetMenu().(*NormalMenu)
+	menu.SetQuitKey('q')
+	vetoes := 0
+	w.BeforeExit(func() bool {
+		vetoes++
+		return vetoes > 1
+	})
+	// escape isn't the quit key anymore, the first q is vetoed
+	screen.PushKeys(KeyEscape, 'q', 'q', KeyEscape)
+	must(t, w.Start())
+	if vetoes != 2 {
+		t.Fatalf("the hook was called %d times, want 2", vetoes)
+	}
+	if w.Result() != nil {
+		t.Fatalf("got result %v, want nil", w.Result())
+	}
+}
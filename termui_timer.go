@@ -0,0 +1,112 @@
+package termui
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// Shortest interval of the repeating timers
+	minTimerInterval = time.Millisecond
+)
+
+// A timer of the window. The function of the timer is called on the goroutine that runs the window
+type Timer struct {
+	window  *Window
+	timer   *time.Timer
+	lock    sync.Mutex
+	stopped bool
+	queued  bool
+}
+
+// Stops the timer. The function won't be called after Stop returns
+func (t *Timer) Stop() {
+	t.lock.Lock()
+	t.stopped = true
+	t.timer.Stop()
+	t.lock.Unlock()
+	t.window.removeTimer(t)
+}
+
+// Returns true if the timer was stopped
+func (t *Timer) isStopped() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.stopped
+}
+
+// Creates the timer that queues fn after d. If repeat is true, the timer queues fn every d.
+// The ticks of the repeating timer are skipped while fn is still queued
+func (w *Window) newTimer(d time.Duration, fn func(), repeat bool) *Timer {
+	if repeat && d < minTimerInterval {
+		d = minTimerInterval
+	}
+	result := &Timer{}
+	result.window = w
+	result.lock.Lock()
+	defer result.lock.Unlock()
+	result.timer = time.AfterFunc(d, func() {
+		result.lock.Lock()
+		if !result.stopped && repeat {
+			result.timer.Reset(d)
+		}
+		skip := result.queued
+		result.queued = true
+		result.lock.Unlock()
+		if skip {
+			return
+		}
+		w.QueueUpdate(func() {
+			result.lock.Lock()
+			result.queued = false
+			result.lock.Unlock()
+			if result.isStopped() {
+				return
+			}
+			if !repeat {
+				result.Stop()
+			}
+			fn()
+		})
+	})
+	w.timerLock.Lock()
+	w.timers[result] = struct{}{}
+	w.timerLock.Unlock()
+	return result
+}
+
+// Calls fn once after d, then redraws the window. If d isn't positive, fn is called as soon as possible
+//
+// Safe to call from any goroutine
+func (w *Window) AfterFunc(d time.Duration, fn func()) *Timer {
+	return w.newTimer(d, fn, false)
+}
+
+// Calls fn every d until the timer is stopped, redraws the window after every call.
+// Intervals shorter than a millisecond are raised to a millisecond.
+// If the window is busy for longer than d, the missed calls are dropped
+//
+// Safe to call from any goroutine
+func (w *Window) Every(d time.Duration, fn func()) *Timer {
+	return w.newTimer(d, fn, true)
+}
+
+// Removes the timer from the window
+func (w *Window) removeTimer(t *Timer) {
+	w.timerLock.Lock()
+	delete(w.timers, t)
+	w.timerLock.Unlock()
+}
+
+// Stops all the timers of the window
+func (w *Window) stopTimers() {
+	w.timerLock.Lock()
+	timers := make([]*Timer, 0, len(w.timers))
+	for t := range w.timers {
+		timers = append(timers, t)
+	}
+	w.timerLock.Unlock()
+	for _, t := range timers {
+		t.Stop()
+	}
+}
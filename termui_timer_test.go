@@ -0,0 +1,94 @@
+package termui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAfterFunc(t *testing.T) {
+	w, screen := newTestWindow(t, 5, 30)
+	label, err := NewLabel(w.GetMenu(), 1, 1, "waiting")
+	must(t, err)
+	input := make(chanInput)
+	w.SetInput(input)
+	calls := 0
+	w.AfterFunc(time.Millisecond, func() {
+		calls++
+		label.SetText("fired")
+	})
+	stopped := w.AfterFunc(time.Millisecond, func() {
+		t.Error("the stopped timer fired")
+	})
+	stopped.Stop()
+	done := make(chan error)
+	go func() {
+		done <- w.Start()
+	}()
+	waitForText(t, screen, "fired")
+	w.AfterFunc(20*time.Millisecond, func() {
+		close(input)
+	})
+	must(t, <-done)
+	if calls != 1 {
+		t.Fatalf("the timer fired %v times", calls)
+	}
+}
+
+func TestEvery(t *testing.T) {
+	w, _ := newTestWindow(t, 5, 30)
+	input := make(chanInput)
+	w.SetInput(input)
+	calls := 0
+	var timer *Timer
+	timer = w.Every(time.Millisecond, func() {
+		calls++
+		if calls == 3 {
+			timer.Stop()
+			// the window keeps running long enough for the stopped timer to fire again
+			w.AfterFunc(20*time.Millisecond, func() {
+				close(input)
+			})
+		}
+	})
+	must(t, w.Start())
+	if calls != 3 {
+		t.Fatalf("the timer fired %v times, want 3", calls)
+	}
+}
+
+func TestEverySkipsQueuedTicks(t *testing.T) {
+	w, _ := newTestWindow(t, 5, 30)
+	input := make(chanInput)
+	w.SetInput(input)
+	calls := 0
+	queued := 0
+	var timer *Timer
+	// the interval isn't positive, so the shortest one is used
+	timer = w.Every(0, func() {
+		calls++
+		if calls == 1 {
+			// the ticks that come while the window is busy are queued once
+			time.Sleep(30 * time.Millisecond)
+			w.updateLock.Lock()
+			queued = len(w.updates)
+			w.updateLock.Unlock()
+			return
+		}
+		timer.Stop()
+		close(input)
+	})
+	must(t, w.Start())
+	if calls != 2 || queued != 1 {
+		t.Fatalf("the timer fired %v times with %v queued ticks, want 2 times with 1", calls, queued)
+	}
+}
+
+func TestExitStopsTimers(t *testing.T) {
+	w, _ := newTestWindow(t, 5, 30)
+	w.SetInput(NewKeysInput())
+	timer := w.Every(time.Hour, func() {})
+	must(t, w.Start())
+	if !timer.isStopped() || len(w.timers) != 0 {
+		t.Fatal("the timer wasn't stopped on the exit")
+	}
+}
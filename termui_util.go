@@ -3,14 +3,12 @@ package termui
 import (
 	"fmt"
 	"strings"
-
-	nc "github.com/rthornton128/goncurses"
 )
 
 type DDBChoiceType int
 
 const (
-	focusedAttribute = nc.A_REVERSE
+	focusedAttribute = AttrReverse
 
 	SingleElement DDBChoiceType = iota
 	MultipleElements
@@ -77,13 +75,22 @@ func SumInt(a ...int) int {
 	return result
 }
 
-// More convinient way to add to window with multiple attributes
-func Put(win *nc.Window, y, x int, line string, attrs ...nc.Char) {
-	for _, attr := range attrs {
-		win.AttrOn(attr)
-		defer win.AttrOff(attr)
+// More convinient way to add to surface with multiple attributes
+func Put(s Surface, y, x int, line string, attrs ...Attr) {
+	attr := joinAttrs(attrs...)
+	for _, ch := range line {
+		s.SetCell(y, x, ch, attr)
+		x++
+	}
+}
+
+// Fills the area of the surface with spaces
+func clearArea(s Surface, y, x, height, width int) {
+	for i := 0; i < height; i++ {
+		for j := 0; j < width; j++ {
+			s.SetCell(y+i, x+j, ' ', AttrNormal)
+		}
 	}
-	win.MovePrint(y, x, line)
 }
 
 func ReverseColorPair(colorPair string) string {
@@ -99,44 +106,101 @@ func ReverseColorPair(colorPair string) string {
 }
 
 // Draws a box
-func DrawBox(win *nc.Window, y, x, height, width int, borderColor string) error {
+func DrawBox(s Surface, y, x, height, width int, borderColor string) error {
 	bcolor, err := ParseColorPair(borderColor)
 	if err != nil {
 		return err
 	}
-	win.AttrOn(bcolor)
-	win.MoveAddChar(y, x, nc.ACS_ULCORNER)
-	win.MoveAddChar(y+height-1, x, nc.ACS_LLCORNER)
-	win.MoveAddChar(y, x+width-1, nc.ACS_URCORNER)
-	win.MoveAddChar(y+height-1, x+width-1, nc.ACS_LRCORNER)
+	s.SetCell(y, x, runeULCorner, bcolor)
+	s.SetCell(y+height-1, x, runeLLCorner, bcolor)
+	s.SetCell(y, x+width-1, runeURCorner, bcolor)
+	s.SetCell(y+height-1, x+width-1, runeLRCorner, bcolor)
 	for i := 1; i < height-1; i++ {
-		win.MoveAddChar(y+i, x, nc.ACS_VLINE)
-		win.MoveAddChar(y+i, x+width-1, nc.ACS_VLINE)
+		s.SetCell(y+i, x, runeVLine, bcolor)
+		s.SetCell(y+i, x+width-1, runeVLine, bcolor)
 	}
 	for i := 1; i < width-1; i++ {
-		win.MoveAddChar(y, x+i, nc.ACS_HLINE)
-		win.MoveAddChar(y+height-1, x+i, nc.ACS_HLINE)
+		s.SetCell(y, x+i, runeHLine, bcolor)
+		s.SetCell(y+height-1, x+i, runeHLine, bcolor)
 	}
-	win.AttrOff(bcolor)
 	return nil
 }
 
-// Draws the borders of the window
-func DrawBorders(win *nc.Window, colorPair string) error {
-	var err error
-	color, err := ParseColorPair(colorPair)
-	if err != nil {
-		return err
+// Draws the borders of the surface
+func DrawBorders(s Surface, colorPair string) error {
+	height, width := s.MaxYX()
+	return DrawBox(s, 0, 0, height, width, colorPair)
+}
+
+// A message box layer
+type messageBox struct {
+	parent      *Window
+	cctMessage  *CCTMessage
+	cctChoices  []*CCTMessage
+	choices     []string
+	choiceID    int
+	hasCancel   bool
+	borderColor string
+	result      string
+}
+
+// Returns the location and the size of the box, centered on the screen
+func (b messageBox) area() (int, int, int, int) {
+	choicesLen := (len(b.cctChoices) + 1) * 2
+	for _, ch := range b.cctChoices {
+		choicesLen += ch.Length()
 	}
-	win.AttrOn(color)
-	err = win.Border(nc.ACS_VLINE, nc.ACS_VLINE, nc.ACS_HLINE, nc.ACS_HLINE, nc.ACS_ULCORNER, nc.ACS_URCORNER, nc.ACS_LLCORNER, nc.ACS_LRCORNER)
+	wwidth := MaxInt(choicesLen, b.cctMessage.Length()+4)
+	wheight := 7
+	height, width := b.parent.GetMaxYX()
+	return (height - wheight) / 2, (width - wwidth) / 2, wheight, wwidth
+}
+
+// Draws the box over the window
+func (b messageBox) Draw(s Surface) error {
+	y, x, wheight, wwidth := b.area()
+	win := NewSubSurface(s, y, x, wheight, wwidth)
+	clearArea(win, 0, 0, wheight, wwidth)
+	err := DrawBorders(win, b.borderColor)
 	if err != nil {
 		return err
 	}
-	win.AttrOff(color)
+	b.cctMessage.Draw(win, 2, 2)
+	pos := 3
+	for i, choice := range b.cctChoices {
+		sl := choice.Length()
+		if i == b.choiceID {
+			Put(win, wheight-3, pos-2, "["+strings.Repeat(" ", sl)+"]")
+		}
+		choice.Draw(win, wheight-3, pos-1)
+		pos += sl + 2
+	}
 	return nil
 }
 
+// Picks the choice with left/right and closes the box on enter (or escape, if there is the Cancel choice)
+func (b *messageBox) HandleKey(key Key) (bool, error) {
+	switch {
+	case key == KeyLeft:
+		b.choiceID--
+		if b.choiceID < 0 {
+			b.choiceID = len(b.choices) - 1
+		}
+	case key == KeyRight:
+		b.choiceID++
+		if b.choiceID >= len(b.choices) {
+			b.choiceID = 0
+		}
+	case key == KeyEnter:
+		b.result = b.choices[b.choiceID]
+		b.parent.RemoveLayer(b)
+	case key == KeyEscape && b.hasCancel:
+		b.result = "Cancel"
+		b.parent.RemoveLayer(b)
+	}
+	return true, nil
+}
+
 // Displays a message box
 // Choices can't be more than 3 elements
 // If choices is empty, it becomes {"Ok"}
@@ -145,207 +209,219 @@ func MessageBox(parent *Window, message string, choices []string, borderColor st
 	if len(choices) == 0 {
 		choices = []string{"Ok"}
 	}
-	hasCancel := false
+	if len(choices) > 3 {
+		return "", fmt.Errorf("termui - %v can't be choices for MessageBox", choices)
+	}
+	box := messageBox{}
+	box.parent = parent
+	box.choices = choices
+	box.borderColor = borderColor
 	for _, choice := range choices {
 		if choice == "Cancel" {
-			hasCancel = true
+			box.hasCancel = true
 		}
 	}
-	parentWin := parent.win
-	height, width := parentWin.MaxYX()
-	if len(choices) > 3 {
-		return "", fmt.Errorf("termui - %v can't be choices for MessageBox", choices)
-	}
-	cctChoices, err := GetCCTs(choices)
+	var err error
+	box.cctChoices, err = GetCCTs(choices)
 	if err != nil {
 		return "", err
 	}
-	cctMessage, err := ToCCTMessage(message)
+	box.cctMessage, err = ToCCTMessage(message)
 	if err != nil {
 		return "", err
 	}
-	choiceID := 0
-	done := false
-	// maxWidth := width - 2
-	choicesLen := (len(cctChoices) + 1) * 2
-	for _, ch := range cctChoices {
-		choicesLen += ch.Length()
-	}
-	wwidth := MaxInt(choicesLen, cctMessage.Length()+4)
-	wheight := 7
-	ypos := (height - wheight) / 2
-	xpos := (width - wwidth) / 2
-	win, err := nc.NewWindow(wheight, wwidth, ypos, xpos)
+	err = parent.RunLayer(&box)
 	if err != nil {
 		return "", err
 	}
-	err = win.Keypad(true)
+	return box.result, nil
+}
+
+// A drop down box layer
+type dropDownBox struct {
+	parent           *Window
+	y, x             int
+	height, width    int
+	maxDisplayAmount int
+	optionCount      int
+	lt               *ListTemplate
+	borderColor      string
+	result           []int
+}
+
+// Draws the box over the window
+func (b dropDownBox) Draw(s Surface) error {
+	win := NewSubSurface(s, b.y, b.x, b.height, b.width)
+	bc, err := ParseColorPair(b.borderColor)
 	if err != nil {
-		return "", err
+		return err
 	}
-	defer win.Clear()
-	DrawBorders(win, borderColor)
-	cctMessage.Draw(win, 2, 2)
-	// put(win, 2, 2, message)
-	whiteSpace := strings.Repeat(" ", wwidth-2)
+	// clear lines
+	err = DrawBorders(win, b.borderColor)
+	if err != nil {
+		return err
+	}
+	win.SetCell(1, b.width-1, runeVLine, bc)
+	win.SetCell(b.height-2, b.width-1, runeVLine, bc)
+	clearArea(win, 1, 1, b.height-2, b.width-2)
+	// draw
+	b.lt.Draw(win, 1, 1, true)
+	if b.optionCount > b.maxDisplayAmount {
+		if b.lt.pageN != 0 {
+			win.SetCell(1, b.width-1, runeUArrow, bc)
+		}
+		if b.lt.pageN != b.optionCount-b.maxDisplayAmount {
+			win.SetCell(b.height-2, b.width-1, runeDArrow, bc)
+		}
+	}
+	return nil
+}
 
-	for !done {
-		// draw
-		pos := 3
-		Put(win, wheight-3, 1, whiteSpace)
-		for i, choice := range cctChoices {
-			// nc.Flash()
-			sl := choice.Length()
-			if i == choiceID {
-				Put(win, wheight-3, pos-2, "["+strings.Repeat(" ", sl)+"]")
-			}
-			choice.Draw(win, wheight-3, pos-1)
-			// put(win, wheight-3, pos-1, s)
-			pos += sl + 2
+// Closes the box with the picked option
+func (b *dropDownBox) pick(result []int) {
+	b.result = result
+	b.parent.RemoveLayer(b)
+}
+
+// Scrolls the options with up/down, picks the option on enter, closes the box on escape.
+// The mouse wheel scrolls the options, a click picks the option or closes the box
+func (b *dropDownBox) HandleKey(key Key) (bool, error) {
+	switch key {
+	case KeyEscape:
+		b.pick(nil)
+	case KeyUp:
+		b.lt.ScrollUp()
+	case KeyDown:
+		b.lt.ScrollDown()
+	case KeyEnter:
+		if b.lt.choice != -1 {
+			b.pick([]int{b.lt.choice})
 		}
-		// key handling
-		key := win.GetChar()
-		if key == nc.KEY_LEFT {
-			choiceID--
-			if choiceID < 0 {
-				choiceID = len(choices) - 1
+	case KeyMouse:
+		event := b.parent.GetMouse()
+		switch {
+		case event.Button == MouseWheelUp:
+			b.lt.ScrollUp()
+		case event.Button == MouseWheelDown:
+			b.lt.ScrollDown()
+		case event.IsClick():
+			if event.Y < b.y || event.Y >= b.y+b.height || event.X < b.x || event.X >= b.x+b.width {
+				// click outside of the box closes it
+				b.pick(nil)
+				break
 			}
-		}
-		if key == nc.KEY_RIGHT {
-			choiceID++
-			if choiceID >= len(choices) {
-				choiceID = 0
+			if b.lt.SelectRow(event.Y - b.y - 1) {
+				b.pick([]int{b.lt.choice})
 			}
 		}
-		if key == 10 { // nc.KEY_ENTER doesn't work
-			done = true
-		}
-		if key == nc.KEY_ESC && hasCancel {
-			return "Cancel", nil
-		}
 	}
-	return choices[choiceID], nil
+	return true, nil
 }
 
-// Displays a drop down box
+// Displays a drop down box. The mouse wheel scrolls the options, a click picks the option or closes the box
 // Returns the indicies of the picked options
-func DropDownBox(options []string, maxDisplayAmount, y, x int, choiceType DDBChoiceType, borderColor string) ([]int, error) {
+func DropDownBox(parent *Window, options []string, maxDisplayAmount, y, x int, choiceType DDBChoiceType, borderColor string) ([]int, error) {
 	if len(options) == 0 {
 		return nil, nil
 	}
-	// height := minInt(len(options), maxDisplayAmount) + 2
-	height := maxDisplayAmount + 2
 	cctOptions, err := GetCCTs(options)
 	if err != nil {
 		return nil, err
 	}
-	width := cctOptions[0].Length()
-	for i, line := range cctOptions {
-		if i == 0 {
-			continue
-		}
-		l := line.Length()
-		if l > width {
-			width = l
-		}
-	}
-	width += 3
-	win, err := nc.NewWindow(height, width, y, x)
+	_, err = ParseColorPair(borderColor)
 	if err != nil {
 		return nil, err
 	}
-	defer win.Clear()
-	win.Keypad(true)
-	DrawBorders(win, borderColor)
+	box := dropDownBox{}
+	box.parent = parent
+	box.y = y
+	box.x = x
+	box.maxDisplayAmount = maxDisplayAmount
+	box.optionCount = len(options)
+	box.borderColor = borderColor
+	box.height = maxDisplayAmount + 2
+	box.width = cctOptions[0].Length()
+	for _, line := range cctOptions[1:] {
+		box.width = MaxInt(box.width, line.Length())
+	}
+	box.width += 3
 	moptions := make([]DrawableAsLine, 0, len(cctOptions))
 	for _, o := range cctOptions {
 		moptions = append(moptions, o)
 	}
-	lt := CreateListTemplate(moptions, maxDisplayAmount)
-	whiteSpace := strings.Repeat(" ", width-2)
-	bc, err := ParseColorPair(borderColor)
+	box.lt = CreateListTemplate(moptions, maxDisplayAmount)
+	err = parent.RunLayer(&box)
 	if err != nil {
 		return nil, err
 	}
-	for {
-		// clear lines
-		win.AttrOn(bc)
-		win.MoveAddChar(1, width-1, nc.ACS_VLINE)
-		win.MoveAddChar(height-2, width-1, nc.ACS_VLINE)
-		win.AttrOff(bc)
-		for i := 1; i < height-1; i++ {
-			Put(win, i, 1, whiteSpace)
-		}
-		// draw
-		lt.Draw(win, 1, 1, true)
-		win.AttrOn(bc)
-		if len(options) > maxDisplayAmount {
-			if lt.pageN != 0 {
-				win.MoveAddChar(1, width-1, nc.ACS_UARROW)
-			}
-			if lt.pageN != len(options)-maxDisplayAmount {
-				win.MoveAddChar(height-2, width-1, nc.ACS_DARROW)
-			}
-		}
-		win.AttrOff(bc)
-		// handle key
-		key := win.GetChar()
-		switch key {
-		case nc.KEY_ESC:
-			return nil, nil
-		case nc.KEY_UP:
-			lt.ScrollUp()
-		case nc.KEY_DOWN:
-			lt.ScrollDown()
-		case 10:
-			if lt.choice == -1 {
-				break
-			}
-			return []int{lt.choice}, nil
-		}
+	return box.result, nil
+}
+
+// A layer with a line edit
+type enterStringBox struct {
+	parent      *Window
+	cctPrompt   *CCTMessage
+	let         *LineEditTemplate
+	maxLength   int
+	borderColor string
+}
+
+// Returns the location and the size of the box, centered on the screen
+func (b enterStringBox) area() (int, int, int, int) {
+	height := 5
+	width := 2 + b.cctPrompt.Length() + 2 + b.maxLength + 2
+	pheight, pwidth := b.parent.GetMaxYX()
+	return (pheight - height) / 2, (pwidth - width) / 2, height, width
+}
+
+// Draws the box over the window
+func (b enterStringBox) Draw(s Surface) error {
+	y, x, height, width := b.area()
+	w := NewSubSurface(s, y, x, height, width)
+	clearArea(w, 0, 0, height, width)
+	err := DrawBorders(w, b.borderColor)
+	if err != nil {
+		return err
 	}
+	b.cctPrompt.Draw(w, 2, 2)
+	lx := b.cctPrompt.Length() + 4
+	Put(w, 2, lx-2, ": ")
+	return b.let.Draw(w, 2, lx, true)
+}
+
+// Edits the line, closes the box on enter
+func (b *enterStringBox) HandleKey(key Key) (bool, error) {
+	switch key {
+	case KeyEnter:
+		b.parent.RemoveLayer(b)
+	case KeyLeft:
+		b.let.MoveCursorLeft()
+	case KeyRight:
+		b.let.MoveCursorRight()
+	case KeyBackspace:
+		b.let.DeleteSelected()
+	default:
+		b.let.AddCh(rune(key))
+	}
+	return true, nil
 }
 
 // Displays a box where the user will have to enter a string
 // Returns the entered string
 func EnterString(parent *Window, text string, prompt string, maxLength int, borderColor string) (string, error) {
-	pheight, pwidth := parent.win.MaxYX()
 	cctprompt, err := ToCCTMessage(prompt)
 	if err != nil {
 		return "", err
 	}
-	height := 5
-	width := 2 + cctprompt.Length() + 2 + maxLength + 2
-	y := (pheight - height) / 2
-	x := (pwidth - width) / 2
-	w, err := nc.NewWindow(height, width, y, x)
+	box := enterStringBox{}
+	box.parent = parent
+	box.cctPrompt = cctprompt
+	box.maxLength = maxLength
+	box.borderColor = borderColor
+	box.let = CreateLineEditTemplate(text, maxLength)
+	err = parent.RunLayer(&box)
 	if err != nil {
 		return "", err
 	}
-	defer w.Clear()
-	w.Keypad(true)
-	DrawBorders(w, borderColor)
-	y = 2
-	x = cctprompt.Length() + 4
-	cctprompt.Draw(w, y, 2)
-	w.MovePrint(y, x-2, ": ")
-	let := CreateLineEditTemplate(text, maxLength)
-l:
-	for {
-		let.Draw(w, y, x, true)
-		key := w.GetChar()
-		switch key {
-		case KeyEnter:
-			break l
-		case KeyLeft:
-			let.MoveCursorLeft()
-		case KeyRight:
-			let.MoveCursorRight()
-		case KeyBackspace:
-			let.DeleteSelected()
-		default:
-			let.AddCh(rune(key))
-		}
-	}
-	return let.content, nil
+	return box.let.content, nil
 }
@@ -0,0 +1,27 @@
+package termui
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMessageBoxResize(t *testing.T) {
+	w, screen := newTestWindow(t, 12, 40)
+	input := make(chanInput)
+	w.SetInput(input)
+	done := make(chan error)
+	go func() {
+		_, err := MessageBox(w, "Save the ${cyan}file?", []string{"Yes", "Cancel"}, "normal")
+		done <- err
+	}()
+	waitForText(t, screen, "Save the file?")
+	screen.Resize(16, 50)
+	input <- KeyResize
+	input <- KeyEnter
+	must(t, <-done)
+	if height, width := w.GetMaxYX(); height != 16 || width != 50 {
+		t.Fatalf("the window is %vx%v, want 16x50", height, width)
+	}
+	// the box is centered on the resized screen
+	must(t, screen.MatchGolden(filepath.Join("testdata", "messagebox_resized.golden"), *update))
+}
@@ -0,0 +1,362 @@
+package termui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// A cell of the virtual screen
+type VirtualCell struct {
+	Ch   rune
+	Attr Attr
+}
+
+// In-memory screen. Records the drawn cells and color pairs, doesn't require a terminal.
+// The keys are read on the goroutine of the window input while the window draws, so every call is made while holding the lock
+type VirtualScreen struct {
+	lock          sync.Mutex
+	height, width int
+	cells         [][]VirtualCell
+	pairs         map[int16][2]int16
+	keys          []Key
+	mice          []MouseEvent
+	mouse         MouseEvent
+	cursorY       int
+	cursorX       int
+	cursorVisible bool
+	refreshCount  int
+	beepCount     int
+	flashCount    int
+	ended         bool
+}
+
+// Creates a virtual screen of the size
+func NewVirtualScreen(height, width int) *VirtualScreen {
+	result := VirtualScreen{}
+	result.height = height
+	result.width = width
+	result.pairs = map[int16][2]int16{}
+	result.keys = []Key{}
+	result.mice = []MouseEvent{}
+	result.cursorVisible = true
+	result.cells = make([][]VirtualCell, height)
+	for i := range result.cells {
+		result.cells[i] = make([]VirtualCell, width)
+	}
+	result.erase()
+	return &result
+}
+
+// Returns the height and width of the screen
+func (s *VirtualScreen) MaxYX() (int, int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.height, s.width
+}
+
+// Puts the character with the attribute at the location. Characters outside the screen are ignored
+func (s *VirtualScreen) SetCell(y, x int, ch rune, attr Attr) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if y < 0 || x < 0 || y >= s.height || x >= s.width {
+		return
+	}
+	s.cells[y][x] = VirtualCell{Ch: ch, Attr: attr}
+}
+
+// Changes the size of the screen and clears it. Push KeyResize to notify the window
+func (s *VirtualScreen) Resize(height, width int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.height = height
+	s.width = width
+	s.cells = make([][]VirtualCell, height)
+	for i := range s.cells {
+		s.cells[i] = make([]VirtualCell, width)
+	}
+	s.erase()
+}
+
+// Returns the cell at the location
+func (s *VirtualScreen) CellAt(y, x int) VirtualCell {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.cells[y][x]
+}
+
+// Returns the foreground and background colors of the cell at the location
+func (s *VirtualScreen) ColorsAt(y, x int) (int16, int16) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pair := s.cells[y][x].Attr.Pair()
+	if pair == 0 {
+		return -1, -1
+	}
+	colors := s.pairs[pair]
+	return colors[0], colors[1]
+}
+
+// Fills the screen with spaces
+func (s *VirtualScreen) Erase() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.erase()
+}
+
+// Fills the screen with spaces. The lock has to be held
+func (s *VirtualScreen) erase() {
+	for i := range s.cells {
+		for j := range s.cells[i] {
+			s.cells[i][j] = VirtualCell{Ch: ' ', Attr: AttrNormal}
+		}
+	}
+}
+
+// Counts the refresh
+func (s *VirtualScreen) Refresh() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.refreshCount++
+}
+
+// Returns the amount of refreshes
+func (s *VirtualScreen) RefreshCount() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.refreshCount
+}
+
+// Moves the cursor to the location
+func (s *VirtualScreen) MoveCursor(y, x int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.cursorY = y
+	s.cursorX = x
+}
+
+// Returns the location of the cursor
+func (s *VirtualScreen) CursorYX() (int, int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.cursorY, s.cursorX
+}
+
+// Shows or hides the cursor
+func (s *VirtualScreen) SetCursorVisible(visible bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.cursorVisible = visible
+}
+
+// Returns true if the cursor is visible
+func (s *VirtualScreen) CursorVisible() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.cursorVisible
+}
+
+// Adds the keys to the end of the key queue
+func (s *VirtualScreen) PushKeys(keys ...Key) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.keys = append(s.keys, keys...)
+}
+
+// Returns the next key from the key queue.
+// If the queue is empty, returns KeyEscape
+func (s *VirtualScreen) GetKey() Key {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(s.keys) == 0 {
+		return KeyEscape
+	}
+	result := s.keys[0]
+	s.keys = s.keys[1:]
+	if result == KeyMouse && len(s.mice) > 0 {
+		s.mouse = s.mice[0]
+		s.mice = s.mice[1:]
+	}
+	return result
+}
+
+// Adds the mouse events to the end of the key queue, as KeyMouse keys
+func (s *VirtualScreen) PushMouse(events ...MouseEvent) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, event := range events {
+		s.keys = append(s.keys, KeyMouse)
+		s.mice = append(s.mice, event)
+	}
+}
+
+// Returns the mouse event of the last KeyMouse
+func (s *VirtualScreen) LastMouse() MouseEvent {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.mouse
+}
+
+// Records the color pair
+func (s *VirtualScreen) InitPair(pair, fg, bg int16) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.pairs[pair] = [2]int16{fg, bg}
+	return nil
+}
+
+// Counts the beep
+func (s *VirtualScreen) Beep() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.beepCount++
+}
+
+// Returns the amount of beeps
+func (s *VirtualScreen) BeepCount() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.beepCount
+}
+
+// Counts the flash
+func (s *VirtualScreen) Flash() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.flashCount++
+}
+
+// Returns the amount of flashes
+func (s *VirtualScreen) FlashCount() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.flashCount
+}
+
+// Marks the screen as ended
+func (s *VirtualScreen) End() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.ended = true
+}
+
+// Returns true if the screen was ended
+func (s *VirtualScreen) Ended() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.ended
+}
+
+// Returns the contents of the screen as text, one line per row. Trailing spaces are removed
+func (s *VirtualScreen) String() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.text()
+}
+
+// Returns the contents of the screen as text. The lock has to be held
+func (s *VirtualScreen) text() string {
+	lines := make([]string, 0, s.height)
+	for _, row := range s.cells {
+		line := make([]rune, 0, len(row))
+		for _, cell := range row {
+			line = append(line, cell.Ch)
+		}
+		lines = append(lines, strings.TrimRight(string(line), " "))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Names of the text attributes in the style legend
+var attrNames = []struct {
+	attr Attr
+	name string
+}{
+	{AttrReverse, "reverse"},
+	{AttrBold, "bold"},
+	{AttrUnderline, "underline"},
+	{AttrDim, "dim"},
+}
+
+// Returns the description of the style of the cell: the colors of its pair and its text attributes
+func (s *VirtualScreen) styleOf(attr Attr) string {
+	fg, bg := int16(-1), int16(-1)
+	if pair := attr.Pair(); pair != 0 {
+		fg, bg = s.pairs[pair][0], s.pairs[pair][1]
+	}
+	result := fmt.Sprintf("fg=%v bg=%v", fg, bg)
+	for _, a := range attrNames {
+		if attr&a.attr != 0 {
+			result += " " + a.name
+		}
+	}
+	return result
+}
+
+// Returns the styles of the cells. Every row has a letter per cell, the cells of the default style are dots.
+// The legend after the rows maps the letters to the colors and the text attributes. The lock has to be held
+func (s *VirtualScreen) styles() string {
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	defaultStyle := s.styleOf(AttrNormal)
+	keys := map[string]byte{}
+	lines := make([]string, 0, s.height)
+	for _, row := range s.cells {
+		line := make([]byte, 0, len(row))
+		for _, cell := range row {
+			style := s.styleOf(cell.Attr)
+			if style == defaultStyle {
+				line = append(line, '.')
+				continue
+			}
+			key, has := keys[style]
+			if !has {
+				key = '?'
+				if len(keys) < len(letters) {
+					key = letters[len(keys)]
+				}
+				keys[style] = key
+			}
+			line = append(line, key)
+		}
+		lines = append(lines, strings.TrimRight(string(line), "."))
+	}
+	legend := make([]string, 0, len(keys))
+	for style, key := range keys {
+		legend = append(legend, fmt.Sprintf("%c: %v", key, style))
+	}
+	sort.Strings(legend)
+	return strings.Join(lines, "\n") + "\n" + strings.Join(legend, "\n") + "\n"
+}
+
+// Returns the contents of the screen with the styles of the cells, as they are stored in the golden files
+func (s *VirtualScreen) Golden() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.text() + "-- styles\n" + s.styles()
+}
+
+// Compares the contents of the screen and the colors and attributes of the cells with the golden file.
+// If update is true, the golden file is overwritten with the contents instead
+func (s *VirtualScreen) MatchGolden(path string, update bool) error {
+	actual := s.Golden()
+	if update {
+		return os.WriteFile(path, []byte(actual), 0644)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	expected := strings.Split(string(data), "\n")
+	lines := strings.Split(actual, "\n")
+	for i := 0; i < MinInt(len(lines), len(expected)); i++ {
+		if lines[i] != expected[i] {
+			return fmt.Errorf("termui - line %v doesn't match golden file %v:\nexpected: %q\nactual:   %q", i, path, expected[i], lines[i])
+		}
+	}
+	if len(expected) != len(lines) {
+		return fmt.Errorf("termui - golden file %v has %v lines, screen has %v", path, len(expected), len(lines))
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package termui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestVirtualScreenGoldenMenu(t *testing.T) {
+	w, screen := newTestWindow(t, 8, 30)
+	_, err := NewLabel(w.GetMenu(), 1, 1, "${red}Hello, ${normal}World")
+	must(t, err)
+	matchGolden(t, w, screen, "menu")
+}
+
+func TestVirtualScreenGoldenList(t *testing.T) {
+	w, screen := newTestWindow(t, 10, 30)
+	menu := w.GetMenu()
+	options := []DrawableAsLine{}
+	for _, line := range []string{"first", "second", "${green}third", "fourth"} {
+		cct, err := ToCCTMessage(line)
+		must(t, err)
+		options = append(options, cct)
+	}
+	list, err := NewList(menu, 0, 0, options, 3, func(choice, cursor int, option DrawableAsLine) error {
+		return nil
+	}, "normal")
+	must(t, err)
+	menu.Focus(list)
+	_, err = list.HandleKey('>')
+	must(t, err)
+	matchGolden(t, w, screen, "list")
+}
+
+func TestVirtualScreenGoldenPieChart(t *testing.T) {
+	w, screen := newTestWindow(t, 12, 30)
+	_, err := NewPieChart(w.GetMenu(), 0, 0, 10, 20, []int{1, 3}, []string{"red", "blue"}, "normal")
+	must(t, err)
+	matchGolden(t, w, screen, "piechart")
+}
+
+func TestVirtualScreenGoldenMessageBox(t *testing.T) {
+	w, screen := newTestWindow(t, 12, 40)
+	screen.PushKeys(KeyRight, KeyEnter)
+	result, err := MessageBox(w, "Save the ${cyan}file?", []string{"Yes", "Cancel"}, "normal")
+	must(t, err)
+	if result != "Cancel" {
+		t.Fatalf("expected Cancel, got %v", result)
+	}
+	// the screen keeps the last frame the box was drawn in
+	err = screen.MatchGolden(filepath.Join("testdata", "messagebox.golden"), *update)
+	must(t, err)
+}
+
+func TestVirtualScreenMatchGoldenColors(t *testing.T) {
+	screen := NewVirtualScreen(1, 3)
+	must(t, screen.InitPair(1, 1, -1))
+	must(t, screen.InitPair(2, 2, -1))
+	screen.SetCell(0, 0, 'a', colorPairAttr(1))
+	path := filepath.Join(t.TempDir(), "colors.golden")
+	must(t, screen.MatchGolden(path, true))
+	data, err := os.ReadFile(path)
+	must(t, err)
+	if !strings.Contains(string(data), "a: fg=1 bg=-1") {
+		t.Fatalf("golden file has no styles:\n%s", data)
+	}
+	// the same text in another color doesn't match
+	screen.SetCell(0, 0, 'a', colorPairAttr(2))
+	if screen.MatchGolden(path, false) == nil {
+		t.Fatal("golden file matched a different color")
+	}
+	screen.SetCell(0, 0, 'a', colorPairAttr(1)|AttrBold)
+	if screen.MatchGolden(path, false) == nil {
+		t.Fatal("golden file matched a different attribute")
+	}
+	screen.SetCell(0, 0, 'a', colorPairAttr(1))
+	must(t, screen.MatchGolden(path, false))
+}
+
+func TestVirtualScreenConcurrentKeys(t *testing.T) {
+	screen := NewVirtualScreen(5, 5)
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			screen.PushKeys('a')
+			screen.GetKey()
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		screen.SetCell(i%5, i%5, 'x', AttrNormal)
+		screen.Refresh()
+	}
+	wg.Wait()
+	if screen.RefreshCount() != 1000 {
+		t.Fatalf("expected 1000 refreshes, got %v", screen.RefreshCount())
+	}
+}